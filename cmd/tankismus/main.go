@@ -1,19 +1,44 @@
 package main
 
 import (
+	"flag"
 	"log"
 
 	"github.com/hajimehoshi/ebiten/v2"
 
 	"github.com/co0p/tankismus/game"
+	"github.com/co0p/tankismus/pkg/input"
 )
 
 func main() {
+	record := flag.String("record", "", "record input to this file when the game exits")
+	replay := flag.String("replay", "", "replay input recorded with -record from this file")
+	flag.Parse()
+
+	var recorder *input.Recorder
+	switch {
+	case *replay != "":
+		rec, err := input.LoadRecording(*replay)
+		if err != nil {
+			log.Fatal(err)
+		}
+		input.SetManager(input.NewPlayback(rec))
+	case *record != "":
+		recorder = input.NewRecorder(nil)
+		input.SetManager(recorder)
+	}
+
 	g := game.NewGame()
 	ebiten.SetWindowTitle("tankismus")
 	ebiten.SetWindowSize(800, 600)
 	ebiten.SetFullscreen(true)
-	if err := ebiten.RunGame(g); err != nil && err != ebiten.Termination {
+	err := ebiten.RunGame(g)
+	if recorder != nil {
+		if err := recorder.Recording().Save(*record); err != nil {
+			log.Printf("saving input recording: %v", err)
+		}
+	}
+	if err != nil && err != ebiten.Termination {
 		log.Fatal(err)
 	}
 }
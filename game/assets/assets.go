@@ -4,6 +4,7 @@ import (
 	"embed"
 	"errors"
 	"strings"
+	"sync"
 
 	"github.com/hajimehoshi/ebiten/v2"
 	"github.com/hajimehoshi/ebiten/v2/ebitenutil"
@@ -19,6 +20,10 @@ var imagesFS embed.FS
 // Registry maps sprite IDs to loaded Ebiten images.
 var Registry = map[string]*ebiten.Image{}
 
+// registryMu guards Registry so assets can be loaded on a background
+// goroutine while scenes keep drawing.
+var registryMu sync.RWMutex
+
 // ErrTileSpriteNotFound is returned by ComposeTilemap when a tile ID in the
 // map does not have a corresponding sprite registered in the assets registry.
 var ErrTileSpriteNotFound = errors.New("assets: tile sprite not found")
@@ -27,45 +32,66 @@ var ErrTileSpriteNotFound = errors.New("assets: tile sprite not found")
 // It is safe to call multiple times; later calls will simply overwrite
 // existing entries with the same IDs.
 func Load() error {
+	return LoadWithProgress(nil)
+}
+
+// LoadWithProgress is like Load but calls report, if non-nil, after each
+// image with the number of images processed so far and the total. It may be
+// called from any goroutine.
+func LoadWithProgress(report func(done, total int)) error {
 	entries, err := imagesFS.ReadDir("images")
 	if err != nil {
 		return err
 	}
 
+	names := make([]string, 0, len(entries))
 	for _, entry := range entries {
-		if entry.IsDir() {
-			continue
-		}
-		name := entry.Name()
-		if !strings.HasSuffix(name, ".png") {
-			continue
-		}
-
-		img, _, err := ebitenutil.NewImageFromFileSystem(imagesFS, "images/"+name)
-		if err != nil {
-			// Skip images that fail to load; the game can still run,
-			// and ComposeTilemap will surface missing sprites as needed.
+		if entry.IsDir() || !strings.HasSuffix(entry.Name(), ".png") {
 			continue
 		}
+		names = append(names, entry.Name())
+	}
 
-		id := strings.TrimSuffix(name, ".png")
-		if id == "tank" {
-			id = "player_tank"
+	for i, name := range names {
+		loadImage(name)
+		if report != nil {
+			report(i+1, len(names))
 		}
-		Registry[id] = img
 	}
 
 	return nil
 }
 
+// loadImage loads a single embedded PNG into the registry.
+func loadImage(name string) {
+	img, _, err := ebitenutil.NewImageFromFileSystem(imagesFS, "images/"+name)
+	if err != nil {
+		// Skip images that fail to load; the game can still run,
+		// and ComposeTilemap will surface missing sprites as needed.
+		return
+	}
+
+	id := strings.TrimSuffix(name, ".png")
+	if id == "tank" {
+		id = "player_tank"
+	}
+	registryMu.Lock()
+	Registry[id] = img
+	registryMu.Unlock()
+}
+
 // GetSprite returns the Ebiten image for a sprite ID, if loaded.
 func GetSprite(id string) *ebiten.Image {
+	registryMu.RLock()
+	defer registryMu.RUnlock()
 	return Registry[id]
 }
 
 // RegisterSpriteForTest allows tests to inject sprites into the registry
 // without loading from disk.
 func RegisterSpriteForTest(id string, img *ebiten.Image) {
+	registryMu.Lock()
+	defer registryMu.Unlock()
 	Registry[id] = img
 }
 
@@ -105,6 +131,8 @@ func ComposeTilemap(spriteID string, m *mappkg.Map, tileSize int) (*ebiten.Image
 		}
 	}
 
+	registryMu.Lock()
 	Registry[spriteID] = img
+	registryMu.Unlock()
 	return img, nil
 }
@@ -4,8 +4,8 @@ import "github.com/co0p/tankismus/pkg/ecs"
 
 // Type IDs used with the generic ECS world.
 //
-// Note: these values are used as bit positions in a uint64 mask,
-// so the total number of distinct component types must stay below 64.
+// These values are used as bit positions in an ecs.Mask, which grows as
+// needed, so new types can simply be appended to the list.
 const (
 	TypeTransform ecs.ComponentType = iota
 	TypeVelocity
@@ -18,6 +18,7 @@ const (
 	TypeControlIntent
 	TypeMovementParams
 	TypeRenderOrder
+	TypeLocalTransform
 )
 
 // Transform represents position, rotation and uniform scale.
@@ -113,8 +114,25 @@ func (MovementParams) Type() ecs.ComponentType { return TypeMovementParams }
 // RenderOrder represents a simple z-order / render layer for sprite-bearing
 // entities. Lower values are drawn before higher values. When absent, systems
 // should treat the entity as using the default layer.
+//
+// The render system caches the draw order and only re-sorts when a
+// RenderOrder is added, removed or replaced, or marked changed. Code that
+// edits Z in place must call World.MarkChanged(id, TypeRenderOrder).
 type RenderOrder struct {
 	Z int `json:"z"`
 }
 
 func (RenderOrder) Type() ecs.ComponentType { return TypeRenderOrder }
+
+// LocalTransform is an entity's position, rotation and scale relative to its
+// parent in the ECS hierarchy (for example a turret on a tank body). The
+// transform propagation system derives the entity's world-space Transform
+// from it each frame.
+type LocalTransform struct {
+	X        float64 `json:"x"`
+	Y        float64 `json:"y"`
+	Rotation float64 `json:"rotation"`
+	Scale    float64 `json:"scale"`
+}
+
+func (LocalTransform) Type() ecs.ComponentType { return TypeLocalTransform }
@@ -57,3 +57,17 @@ func TestRenderOrderComponentTypeAndDefaults(t *testing.T) {
 		t.Errorf("default RenderOrder.Z = %v, want 0", r.Z)
 	}
 }
+
+func TestNewRegistryCoversAllComponentTypes(t *testing.T) {
+	reg := NewRegistry()
+	for ct := TypeTransform; ct <= TypeLocalTransform; ct++ {
+		name, ok := reg.Name(ct)
+		if !ok {
+			t.Fatalf("component type %v is not registered", ct)
+		}
+		c, ok := reg.New(name)
+		if !ok || c.Type() != ct {
+			t.Fatalf("registry constructor for %q returned %v", name, c)
+		}
+	}
+}
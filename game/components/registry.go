@@ -0,0 +1,23 @@
+package components
+
+import "github.com/co0p/tankismus/pkg/ecs"
+
+// NewRegistry returns a component registry containing every component type
+// defined in this package. The names are used in saved snapshots and other
+// data files, so they must not change once released.
+func NewRegistry() *ecs.Registry {
+	reg := ecs.NewRegistry()
+	reg.Register("transform", func() ecs.Component { return &Transform{} })
+	reg.Register("velocity", func() ecs.Component { return &Velocity{} })
+	reg.Register("player_tag", func() ecs.Component { return &PlayerTag{} })
+	reg.Register("enemy_tag", func() ecs.Component { return &EnemyTag{} })
+	reg.Register("health", func() ecs.Component { return &Health{} })
+	reg.Register("sprite", func() ecs.Component { return &Sprite{} })
+	reg.Register("collider", func() ecs.Component { return &Collider{} })
+	reg.Register("projectile", func() ecs.Component { return &Projectile{} })
+	reg.Register("control_intent", func() ecs.Component { return &ControlIntent{} })
+	reg.Register("movement_params", func() ecs.Component { return &MovementParams{} })
+	reg.Register("render_order", func() ecs.Component { return &RenderOrder{} })
+	reg.Register("local_transform", func() ecs.Component { return &LocalTransform{} })
+	return reg
+}
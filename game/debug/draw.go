@@ -0,0 +1,84 @@
+package debug
+
+import (
+	"fmt"
+	"image/color"
+	"strings"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/ebitenutil"
+	"github.com/hajimehoshi/ebiten/v2/vector"
+
+	"github.com/co0p/tankismus/game/components"
+	"github.com/co0p/tankismus/pkg/ecs"
+)
+
+const (
+	lineHeight = 16
+	panelWidth = 360
+	panelPad   = 6
+)
+
+var (
+	panelColor     = color.RGBA{A: 180}
+	highlightColor = color.RGBA{R: 255, G: 220, A: 255}
+)
+
+// Draw renders the overlay on top of screen. It does nothing while the
+// inspector is hidden.
+func (in *Inspector) Draw(world *ecs.World, screen *ebiten.Image) {
+	if !in.visible {
+		return
+	}
+	sw, sh := screen.Bounds().Dx(), screen.Bounds().Dy()
+	maxLines := (sh - 2*panelPad) / lineHeight
+
+	entities := world.Entities()
+	lines := []string{fmt.Sprintf("ECS inspector (F1) - %d entities, tick %d", len(entities), world.Tick())}
+	for _, id := range entities {
+		if len(lines) == maxLines-1 {
+			lines = append(lines, "...")
+			break
+		}
+		marker := "  "
+		if id == in.selected {
+			marker = "> "
+		}
+		mask, _ := world.Mask(id)
+		lines = append(lines, marker+entityLabel(id)+" "+strings.Join(MaskNames(in.reg, mask), ","))
+	}
+	drawPanel(screen, lines, panelPad)
+
+	if in.selected == 0 {
+		return
+	}
+	details := []string{"Entity " + entityLabel(in.selected)}
+	if parent, ok := world.Parent(in.selected); ok {
+		details = append(details, "parent "+entityLabel(parent))
+	}
+	for _, c := range Describe(world, in.reg, in.selected) {
+		details = append(details, c.Name+": "+c.Value)
+	}
+	drawPanel(screen, details, sw-panelWidth-panelPad)
+	in.highlight(world, screen)
+}
+
+// drawPanel draws lines of text on a translucent panel at the top of the
+// screen, starting at x.
+func drawPanel(screen *ebiten.Image, lines []string, x int) {
+	h := len(lines)*lineHeight + 2*panelPad
+	vector.FillRect(screen, float32(x), float32(panelPad), panelWidth, float32(h), panelColor, false)
+	for i, line := range lines {
+		ebitenutil.DebugPrintAt(screen, line, x+panelPad, 2*panelPad+i*lineHeight)
+	}
+}
+
+// highlight outlines the bounds Pick uses for the selected entity.
+func (in *Inspector) highlight(world *ecs.World, screen *ebiten.Image) {
+	t, ok := ecs.Get[*components.Transform](world, in.selected)
+	if !ok {
+		return
+	}
+	cx, cy, w, h := pickBounds(world, in.selected, t)
+	vector.StrokeRect(screen, float32(cx-w/2), float32(cy-h/2), float32(w), float32(h), 1, highlightColor, false)
+}
@@ -0,0 +1,170 @@
+// Package debug contains in-game developer tools such as the ECS world
+// inspector overlay.
+package debug
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/hajimehoshi/ebiten/v2"
+
+	"github.com/co0p/tankismus/game/components"
+	"github.com/co0p/tankismus/game/systems"
+	"github.com/co0p/tankismus/pkg/ecs"
+	"github.com/co0p/tankismus/pkg/input"
+)
+
+// defaultPickSize is the edge length of the square used to hit-test
+// entities that have a Transform but no Collider.
+const defaultPickSize = 32
+
+// Inspector is a toggleable overlay that lists the entities of an ECS world
+// and shows the live component values of a selected entity.
+type Inspector struct {
+	reg      *ecs.Registry
+	visible  bool
+	selected ecs.EntityID
+}
+
+// NewInspector constructs a hidden inspector that names components using
+// components.NewRegistry.
+func NewInspector() *Inspector {
+	return &Inspector{reg: components.NewRegistry()}
+}
+
+// Visible reports whether the overlay is shown.
+func (in *Inspector) Visible() bool {
+	return in.visible
+}
+
+// Toggle shows or hides the overlay. The visible overlay takes the mouse for
+// itself, so clicks do not also trigger mouse-bound actions such as firing.
+func (in *Inspector) Toggle() {
+	in.visible = !in.visible
+	input.SuppressMouseActions(in.visible)
+}
+
+// Selected returns the selected entity, or false when nothing is selected.
+func (in *Inspector) Selected() (ecs.EntityID, bool) {
+	return in.selected, in.selected != 0
+}
+
+// Select selects an entity. Passing zero clears the selection.
+func (in *Inspector) Select(id ecs.EntityID) {
+	in.selected = id
+}
+
+// Update toggles the overlay on ActionDebugToggle and, while it is visible,
+// selects the entity under a left click, converted to world coordinates with
+// the Camera resource. Both come from the latest Poll, so they are recorded
+// and replayed like gameplay input. Selections of destroyed entities are
+// cleared.
+func (in *Inspector) Update(world *ecs.World) {
+	if input.IsActionJustPressed(input.ActionDebugToggle) {
+		in.Toggle()
+	}
+	if in.selected != 0 && !world.IsAlive(in.selected) {
+		in.selected = 0
+	}
+	if !in.visible {
+		return
+	}
+	if input.IsMouseButtonJustPressed(input.MouseButton(ebiten.MouseButtonLeft)) {
+		x, y := systems.CursorWorldPosition(world)
+		in.HandleClick(world, x, y)
+	}
+}
+
+// HandleClick selects the entity under the given world position, or clears
+// the selection when the click hits nothing.
+func (in *Inspector) HandleClick(world *ecs.World, x, y float64) {
+	id, _ := Pick(world, x, y)
+	in.selected = id
+}
+
+// Pick returns the topmost entity whose bounds contain the world position
+// (x, y). Bounds come from the entity's Collider, centred on its Transform,
+// or a small square around the Transform when it has none. Among overlapping
+// entities the one with the highest RenderOrder wins, then the newest slot.
+func Pick(world *ecs.World, x, y float64) (ecs.EntityID, bool) {
+	var (
+		best  ecs.EntityID
+		bestZ int
+	)
+	ecs.NewQuery1[*components.Transform](world).Each(func(id ecs.EntityID, t *components.Transform) {
+		cx, cy, w, h := pickBounds(world, id, t)
+		if x < cx-w/2 || x > cx+w/2 || y < cy-h/2 || y > cy+h/2 {
+			return
+		}
+		z := 0
+		if ro, ok := ecs.Get[*components.RenderOrder](world, id); ok {
+			z = ro.Z
+		}
+		// Find yields entities in slot order, so >= prefers later slots.
+		if best == 0 || z >= bestZ {
+			best, bestZ = id, z
+		}
+	})
+	return best, best != 0
+}
+
+// pickBounds returns the centre and size of the box Pick tests an entity
+// against.
+func pickBounds(world *ecs.World, id ecs.EntityID, t *components.Transform) (cx, cy, w, h float64) {
+	cx, cy = t.X, t.Y
+	w, h = defaultPickSize, defaultPickSize
+	if c, ok := ecs.Get[*components.Collider](world, id); ok {
+		cx += c.OffsetX
+		cy += c.OffsetY
+		w, h = c.Width, c.Height
+	}
+	return cx, cy, w, h
+}
+
+// ComponentInfo is a named component value of an inspected entity.
+type ComponentInfo struct {
+	Name  string
+	Value string
+}
+
+// Describe returns the components of an entity in ascending type order,
+// named through reg and rendered as JSON. Types missing from reg are shown
+// by their numeric ComponentType.
+func Describe(world *ecs.World, reg *ecs.Registry, id ecs.EntityID) []ComponentInfo {
+	mask, ok := world.Mask(id)
+	if !ok {
+		return nil
+	}
+	infos := make([]ComponentInfo, 0)
+	for _, t := range mask.Types() {
+		c, _ := world.GetComponent(id, t)
+		value, err := json.Marshal(c)
+		if err != nil {
+			value = []byte(fmt.Sprintf("%+v", c))
+		}
+		infos = append(infos, ComponentInfo{Name: componentName(reg, t), Value: string(value)})
+	}
+	return infos
+}
+
+// MaskNames decodes a component mask into registered component names.
+func MaskNames(reg *ecs.Registry, mask ecs.Mask) []string {
+	types := mask.Types()
+	names := make([]string, 0, len(types))
+	for _, t := range types {
+		names = append(names, componentName(reg, t))
+	}
+	return names
+}
+
+func componentName(reg *ecs.Registry, t ecs.ComponentType) string {
+	if name, ok := reg.Name(t); ok {
+		return name
+	}
+	return fmt.Sprintf("type %d", t)
+}
+
+// entityLabel formats an entity ID as slot:generation.
+func entityLabel(id ecs.EntityID) string {
+	return fmt.Sprintf("#%d:%d", id.Index(), id.Generation())
+}
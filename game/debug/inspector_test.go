@@ -0,0 +1,73 @@
+package debug
+
+import (
+	"testing"
+
+	"github.com/co0p/tankismus/game/components"
+	"github.com/co0p/tankismus/pkg/ecs"
+)
+
+func TestPick_PrefersHighestRenderOrder(t *testing.T) {
+	w := ecs.NewWorld()
+	ground := w.NewEntity()
+	w.AddComponent(ground, &components.Transform{X: 100, Y: 100, Scale: 1})
+	w.AddComponent(ground, &components.Collider{Width: 400, Height: 400})
+	w.AddComponent(ground, &components.RenderOrder{Z: 0})
+
+	tank := w.NewEntity()
+	w.AddComponent(tank, &components.Transform{X: 120, Y: 100, Scale: 1})
+	w.AddComponent(tank, &components.Collider{Width: 20, Height: 20})
+	w.AddComponent(tank, &components.RenderOrder{Z: 10})
+
+	if got, ok := Pick(w, 125, 95); !ok || got != tank {
+		t.Fatalf("Pick on tank = %v, %v; want %v", got, ok, tank)
+	}
+	if got, ok := Pick(w, 20, 20); !ok || got != ground {
+		t.Fatalf("Pick on ground = %v, %v; want %v", got, ok, ground)
+	}
+	if _, ok := Pick(w, 1000, 1000); ok {
+		t.Fatalf("Pick outside all bounds reported a hit")
+	}
+}
+
+func TestInspector_HandleClickSelectsAndClears(t *testing.T) {
+	w := ecs.NewWorld()
+	id := w.NewEntity()
+	w.AddComponent(id, &components.Transform{X: 50, Y: 50, Scale: 1})
+
+	in := NewInspector()
+	in.HandleClick(w, 55, 45)
+	if got, ok := in.Selected(); !ok || got != id {
+		t.Fatalf("Selected() = %v, %v; want %v", got, ok, id)
+	}
+	in.HandleClick(w, 500, 500)
+	if _, ok := in.Selected(); ok {
+		t.Fatalf("click on empty space must clear the selection")
+	}
+}
+
+func TestDescribe_NamesComponentsAndShowsValues(t *testing.T) {
+	w := ecs.NewWorld()
+	id := w.NewEntity()
+	w.AddComponent(id, &components.Health{Current: 40, Max: 100})
+	w.AddComponent(id, &components.Transform{X: 1, Y: 2, Scale: 1})
+
+	got := Describe(w, components.NewRegistry(), id)
+	want := []ComponentInfo{
+		{Name: "transform", Value: `{"x":1,"y":2,"rotation":0,"scale":1}`},
+		{Name: "health", Value: `{"current":40,"max":100}`},
+	}
+	if len(got) != len(want) {
+		t.Fatalf("Describe = %+v, want %+v", got, want)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Fatalf("Describe[%d] = %+v, want %+v", i, got[i], want[i])
+		}
+	}
+
+	mask, _ := w.Mask(id)
+	if names := MaskNames(components.NewRegistry(), mask); len(names) != 2 || names[0] != "transform" || names[1] != "health" {
+		t.Fatalf("MaskNames = %v, want [transform health]", names)
+	}
+}
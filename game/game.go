@@ -18,6 +18,7 @@ type Game struct {
 
 // NewGame constructs a new Game wired to the start scene.
 func NewGame() *Game {
+	loadControls()
 	g := &Game{}
 	// manager is initialized with nil, then StartScene will set itself.
 	m := scene.NewManager(nil)
@@ -28,6 +29,20 @@ func NewGame() *Game {
 	return g
 }
 
+// loadControls applies the active key binding profile saved in the user's
+// config directory. Without a readable file the default bindings stay active.
+func loadControls() {
+	path, err := input.ProfilePath()
+	if err != nil {
+		return
+	}
+	profiles, err := input.LoadProfiles(path)
+	if err != nil {
+		return
+	}
+	input.SetBindings(profiles.Bindings())
+}
+
 func (g *Game) Update() error {
 	if input.ShouldQuit() {
 		return ebiten.Termination
@@ -37,7 +52,8 @@ func (g *Game) Update() error {
 	dt := now.Sub(g.lastTime).Seconds()
 	g.lastTime = now
 
-	g.manager.Update(dt)
+	// Recorded input replays with its recorded frame times.
+	g.manager.Update(input.BeginFrame(dt))
 	return nil
 }
 
@@ -0,0 +1,53 @@
+// Package prefabs provides the entity templates (tanks, enemy variants,
+// projectiles, crates) that Tankismus spawns into its ECS world.
+package prefabs
+
+import (
+	"bytes"
+	_ "embed"
+	"sync"
+
+	"github.com/co0p/tankismus/game/components"
+	"github.com/co0p/tankismus/pkg/ecs"
+)
+
+// Names of the prefabs defined in prefabs.json.
+const (
+	Tank           = "tank"
+	PlayerTank     = "player_tank"
+	EnemyTank      = "enemy_tank"
+	EnemyTankFast  = "enemy_tank_fast"
+	EnemyTankHeavy = "enemy_tank_heavy"
+	Projectile     = "projectile"
+	Crate          = "crate"
+)
+
+//go:embed prefabs.json
+var data []byte
+
+var (
+	loadOnce sync.Once
+	library  *ecs.PrefabLibrary
+	loadErr  error
+)
+
+// Load parses the embedded prefab definitions into a new library using the
+// component names from components.NewRegistry.
+func Load() (*ecs.PrefabLibrary, error) {
+	lib := ecs.NewPrefabLibrary(components.NewRegistry())
+	if err := lib.Load(bytes.NewReader(data)); err != nil {
+		return nil, err
+	}
+	return lib, nil
+}
+
+// Library returns the shared prefab library, loading it on first use. The
+// prefab data is embedded in the binary and covered by tests, so Library
+// panics if it cannot be parsed.
+func Library() *ecs.PrefabLibrary {
+	loadOnce.Do(func() { library, loadErr = Load() })
+	if loadErr != nil {
+		panic(loadErr)
+	}
+	return library
+}
@@ -0,0 +1,47 @@
+package prefabs
+
+import (
+	"testing"
+
+	"github.com/co0p/tankismus/game/components"
+	"github.com/co0p/tankismus/pkg/ecs"
+)
+
+func TestLoad_EveryPrefabSpawns(t *testing.T) {
+	lib, err := Load()
+	if err != nil {
+		t.Fatalf("Load: %v", err)
+	}
+	w := ecs.NewWorld()
+	for _, name := range []string{Tank, PlayerTank, EnemyTank, EnemyTankFast, EnemyTankHeavy, Projectile, Crate} {
+		id, err := lib.Spawn(w, name)
+		if err != nil {
+			t.Fatalf("Spawn(%q): %v", name, err)
+		}
+		if tr, ok := ecs.Get[*components.Transform](w, id); !ok || tr.Scale != 1 {
+			t.Errorf("prefab %q: transform = %+v, want unit scale", name, tr)
+		}
+	}
+}
+
+func TestEnemyVariants_InheritTankDefaults(t *testing.T) {
+	w := ecs.NewWorld()
+	heavy, err := Library().Spawn(w, EnemyTankHeavy)
+	if err != nil {
+		t.Fatalf("Spawn: %v", err)
+	}
+
+	if !w.HasComponent(heavy, components.TypeEnemyTag) || w.HasComponent(heavy, components.TypePlayerTag) {
+		t.Fatalf("heavy enemy must carry only the enemy tag")
+	}
+	mp, _ := ecs.Get[*components.MovementParams](w, heavy)
+	if mp.MaxForwardSpeed != 70 {
+		t.Errorf("MaxForwardSpeed = %v, want heavy override 70", mp.MaxForwardSpeed)
+	}
+	if mp.MaxBackwardSpeed != 80 || mp.LinearDeceleration != 300 {
+		t.Errorf("movement params = %+v, want tank defaults for fields not overridden", *mp)
+	}
+	if h, _ := ecs.Get[*components.Health](w, heavy); h.Max != 250 {
+		t.Errorf("Health.Max = %v, want 250", h.Max)
+	}
+}
@@ -0,0 +1,27 @@
+package resources
+
+import mappkg "github.com/co0p/tankismus/pkg/map"
+
+// DefaultTileSize is the edge length in pixels of a single map tile.
+const DefaultTileSize = 16
+
+// Level is the world resource describing the loaded level map and the size
+// of its tiles in world units. Map is nil when no level could be loaded.
+type Level struct {
+	Map      *mappkg.Map
+	TileSize int
+}
+
+// Camera is the world resource describing which part of the world is on
+// screen. X and Y are the world position of the screen's top-left corner.
+// The run scene draws without scrolling, so both are zero for now.
+type Camera struct {
+	X float64
+	Y float64
+}
+
+// ScreenToWorld converts a position in screen pixels, such as the mouse
+// cursor, to world coordinates.
+func (c Camera) ScreenToWorld(x, y float64) (float64, float64) {
+	return x + c.X, y + c.Y
+}
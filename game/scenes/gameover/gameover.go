@@ -28,7 +28,7 @@ func (s *Scene) OnExit() {}
 func (s *Scene) Update(dt float64) {
 	_ = dt
 	if len(inpututil.PressedKeys()) > 0 {
-		s.manager.SetScene(start.New(s.manager))
+		s.manager.Transition(start.New(s.manager), scene.Wipe{Duration: 0.4, Direction: scene.WipeLeft})
 	}
 }
 
@@ -0,0 +1,135 @@
+package loading
+
+import (
+	"fmt"
+	"image/color"
+	"sync"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/ebitenutil"
+	"github.com/hajimehoshi/ebiten/v2/vector"
+
+	"github.com/co0p/tankismus/pkg/input"
+	"github.com/co0p/tankismus/pkg/scene"
+)
+
+// Reporter receives loading progress as a fraction from 0 to 1 together with
+// a short status line. It is safe to call from any goroutine.
+type Reporter func(fraction float64, status string)
+
+// Task performs the loading work and returns the scene to switch to once it
+// is ready. It runs on a background goroutine and must not touch state that
+// the running scenes use, other than through concurrency-safe APIs.
+type Task func(report Reporter) scene.Scene
+
+var (
+	barBackground = color.RGBA{R: 40, G: 40, B: 40, A: 255}
+	barForeground = color.RGBA{R: 90, G: 170, B: 60, A: 255}
+)
+
+// Scene runs a Task in the background and shows its progress. When the task
+// completes, it fades into the scene the task returned.
+type Scene struct {
+	manager *scene.Manager
+	task    Task
+	back    func() scene.Scene
+	done    chan scene.Scene
+
+	mu       sync.Mutex
+	fraction float64
+	status   string
+	err      error
+
+	started bool
+}
+
+// New constructs a loading scene that runs task when entered. back
+// constructs the scene to return to if the task fails; nil leaves the error
+// on screen.
+func New(manager *scene.Manager, task Task, back func() scene.Scene) *Scene {
+	return &Scene{manager: manager, task: task, back: back, done: make(chan scene.Scene, 1), status: "loading"}
+}
+
+// OnEnter starts the task on a background goroutine. A panic in the task is
+// reported on screen instead of crashing the game.
+func (s *Scene) OnEnter() {
+	if s.started {
+		return
+	}
+	s.started = true
+	go func() {
+		defer func() {
+			if r := recover(); r != nil {
+				s.mu.Lock()
+				s.err = fmt.Errorf("loading failed: %v", r)
+				s.mu.Unlock()
+			}
+		}()
+		s.done <- s.task(s.report)
+	}()
+}
+
+func (s *Scene) OnExit() {}
+
+// report records progress; it is the Reporter handed to the task.
+func (s *Scene) report(fraction float64, status string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.fraction = min(max(fraction, 0), 1)
+	s.status = status
+}
+
+// Progress returns the last reported fraction and status line.
+func (s *Scene) Progress() (float64, string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.fraction, s.status
+}
+
+// Update switches to the loaded scene as soon as the task has finished. After
+// a failure, confirm or pause returns to the scene built by back.
+func (s *Scene) Update(dt float64) {
+	_ = dt
+	if s.failed() {
+		input.Poll()
+		if s.back != nil && (input.IsActionJustPressed(input.ActionMenuConfirm) || input.IsActionJustPressed(input.ActionPause)) {
+			s.manager.Transition(s.back(), scene.Fade{Duration: 0.3})
+		}
+		return
+	}
+	select {
+	case next := <-s.done:
+		s.manager.Transition(next, scene.Fade{Duration: 0.4})
+	default:
+	}
+}
+
+// failed reports whether the task panicked.
+func (s *Scene) failed() bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.err != nil
+}
+
+func (s *Scene) Draw(screen *ebiten.Image) {
+	screen.Fill(color.Black)
+
+	s.mu.Lock()
+	fraction, status, err := s.fraction, s.status, s.err
+	s.mu.Unlock()
+	if err != nil {
+		msg := err.Error()
+		if s.back != nil {
+			msg += "\nPress Enter or Esc to go back"
+		}
+		ebitenutil.DebugPrint(screen, msg)
+		return
+	}
+
+	size := screen.Bounds().Size()
+	barW, barH := float32(size.X)*0.6, float32(12)
+	x, y := (float32(size.X)-barW)/2, float32(size.Y)/2
+	vector.FillRect(screen, x, y, barW, barH, barBackground, false)
+	vector.FillRect(screen, x, y, barW*float32(fraction), barH, barForeground, false)
+	ebitenutil.DebugPrintAt(screen, fmt.Sprintf("%s... %d%%", status, int(fraction*100)), int(x), int(y)-20)
+}
@@ -0,0 +1,88 @@
+package loading
+
+import (
+	"testing"
+	"time"
+
+	"github.com/hajimehoshi/ebiten/v2"
+
+	"github.com/co0p/tankismus/pkg/input"
+	"github.com/co0p/tankismus/pkg/scene"
+)
+
+type stubScene struct{ entered bool }
+
+func (s *stubScene) OnEnter()           { s.entered = true }
+func (s *stubScene) OnExit()            {}
+func (s *stubScene) Update(float64)     {}
+func (s *stubScene) Draw(*ebiten.Image) {}
+
+func TestLoadingScene_ReportsProgressAndSwitchesWhenDone(t *testing.T) {
+	next := &stubScene{}
+	release := make(chan struct{})
+	reported := make(chan struct{})
+
+	m := scene.NewManager(nil)
+	s := New(m, func(report Reporter) scene.Scene {
+		report(0.5, "halfway")
+		close(reported)
+		<-release
+		return next
+	}, nil)
+	m.SetScene(s)
+
+	<-reported
+	if fraction, status := s.Progress(); fraction != 0.5 || status != "halfway" {
+		t.Fatalf("Progress() = %v, %q; want 0.5, \"halfway\"", fraction, status)
+	}
+	m.Update(0.016)
+	if m.Current() != s {
+		t.Fatalf("loading scene switched before the task finished")
+	}
+
+	close(release)
+	deadline := time.Now().Add(time.Second)
+	for m.Current() != next {
+		if time.Now().After(deadline) {
+			t.Fatalf("loading scene did not switch to the loaded scene")
+		}
+		m.Update(0.016)
+		time.Sleep(time.Millisecond)
+	}
+	if !next.entered {
+		t.Fatalf("loaded scene was not entered")
+	}
+}
+
+func TestLoadingScene_ConfirmAfterFailureReturnsToBackScene(t *testing.T) {
+	tm := input.NewTestManager()
+	input.SetManager(tm)
+	defer input.SetManager(nil)
+
+	back := &stubScene{}
+	m := scene.NewManager(nil)
+	s := New(m, func(Reporter) scene.Scene {
+		panic("missing asset")
+	}, func() scene.Scene { return back })
+	m.SetScene(s)
+
+	deadline := time.Now().Add(time.Second)
+	for !s.failed() {
+		if time.Now().After(deadline) {
+			t.Fatalf("panicking task was not reported as a failure")
+		}
+		time.Sleep(time.Millisecond)
+	}
+	m.Update(0.016)
+	if m.Current() != s {
+		t.Fatalf("loading scene left the error screen without a key press")
+	}
+
+	tm.State[input.ActionMenuConfirm] = true
+	for i := 0; i < 60 && m.Current() != back; i++ {
+		m.Update(0.016)
+	}
+	if m.Current() != back || !back.entered {
+		t.Fatalf("confirm on the error screen did not return to the back scene")
+	}
+}
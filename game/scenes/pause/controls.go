@@ -0,0 +1,199 @@
+package pause
+
+import (
+	"fmt"
+	"image/color"
+	"slices"
+	"strings"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/ebitenutil"
+
+	"github.com/co0p/tankismus/pkg/input"
+	"github.com/co0p/tankismus/pkg/scene"
+)
+
+// controlsScene is the settings screen for key bindings. It edits the active
+// binding profile in place, so changes apply immediately, and saves all
+// profiles to the controls file when the player leaves.
+type controlsScene struct {
+	manager *scene.Manager
+	path    string
+
+	profiles *input.Profiles
+	actions  []input.Action
+	// selected indexes actions, followed by the profile, mouse aim and back
+	// rows.
+	selected int
+	// waiting is set while the next key press is captured for the selected
+	// action.
+	waiting bool
+	// settling skips menu handling for the frame after a key was captured.
+	// The captured key may now be bound to a menu action and would otherwise
+	// count as a fresh press of it.
+	settling   bool
+	message    string
+	saveFailed bool
+}
+
+// profilePath locates the controls file; tests replace it.
+var profilePath = input.ProfilePath
+
+// newControls constructs the controls screen for the controls file in the
+// user's config directory.
+func newControls(manager *scene.Manager) *controlsScene {
+	path, err := profilePath()
+	s := newControlsAt(manager, path)
+	if err != nil {
+		s.message = "controls will not be saved: " + err.Error()
+	}
+	return s
+}
+
+func newControlsAt(manager *scene.Manager, path string) *controlsScene {
+	return &controlsScene{manager: manager, path: path, actions: input.Actions()}
+}
+
+func (s *controlsScene) profileRow() int  { return len(s.actions) }
+func (s *controlsScene) mouseAimRow() int { return len(s.actions) + 1 }
+func (s *controlsScene) backRow() int     { return len(s.actions) + 2 }
+
+// OnEnter loads the saved profiles, falling back to the built-in ones.
+func (s *controlsScene) OnEnter() {
+	profiles, err := input.LoadProfiles(s.path)
+	if err != nil {
+		s.message = err.Error()
+		profiles = input.DefaultProfiles()
+	}
+	s.profiles = profiles
+	input.SetBindings(s.profiles.Bindings())
+}
+
+func (s *controlsScene) OnExit() {}
+
+func (s *controlsScene) Update(dt float64) {
+	_ = dt
+	input.Poll()
+	if s.waiting {
+		if keys := input.JustPressedKeys(); len(keys) > 0 {
+			s.assign(keys[0])
+		}
+		return
+	}
+
+	if s.settling {
+		s.settling = false
+		return
+	}
+
+	rows := s.backRow() + 1
+	switch {
+	case input.IsActionJustPressed(input.ActionPause):
+		s.leave()
+	case input.IsActionJustPressed(input.ActionMenuUp):
+		s.selected = (s.selected + rows - 1) % rows
+	case input.IsActionJustPressed(input.ActionMenuDown):
+		s.selected = (s.selected + 1) % rows
+	case input.IsActionJustPressed(input.ActionMenuConfirm):
+		s.confirm()
+	}
+}
+
+// confirm activates the selected row.
+func (s *controlsScene) confirm() {
+	switch s.selected {
+	case s.profileRow():
+		s.nextProfile()
+	case s.mouseAimRow():
+		b := s.profiles.Bindings()
+		b.SetMouseAim(!b.MouseAim())
+	case s.backRow():
+		s.leave()
+	default:
+		s.waiting = true
+		s.message = ""
+	}
+}
+
+// assign binds the captured key to the selected action. Escape cancels, so
+// it always stays available to leave menus.
+func (s *controlsScene) assign(key ebiten.Key) {
+	s.waiting = false
+	s.settling = true
+	if key == ebiten.KeyEscape {
+		return
+	}
+	action := s.actions[s.selected]
+	if conflicts := s.profiles.Bindings().Bind(action, key); len(conflicts) > 0 {
+		s.message = "conflict: " + conflicts[0].String()
+	} else {
+		s.message = ""
+	}
+}
+
+// nextProfile activates the next profile in name order.
+func (s *controlsScene) nextProfile() {
+	names := s.profiles.Names()
+	i := slices.Index(names, s.profiles.Active)
+	s.profiles.Active = names[(i+1)%len(names)]
+	input.SetBindings(s.profiles.Bindings())
+}
+
+// leave saves the profiles and returns to the pause menu. If saving fails
+// the error is shown once; leaving again discards the unsaved changes.
+func (s *controlsScene) leave() {
+	if err := s.profiles.Save(s.path); err != nil && !s.saveFailed {
+		s.saveFailed = true
+		s.message = "could not save controls: " + err.Error() + " (leave again to discard)"
+		return
+	}
+	s.manager.Pop()
+}
+
+func (s *controlsScene) Draw(screen *ebiten.Image) {
+	screen.Fill(color.RGBA{R: 15, G: 15, B: 30, A: 255})
+
+	bindings := s.profiles.Bindings()
+	lines := []string{"Controls", fmt.Sprintf("  %-14s %-28s %s", "", "keyboard / mouse", "gamepad")}
+	for i, a := range s.actions {
+		keys := make([]string, 0)
+		for _, k := range bindings.Keys(a) {
+			keys = append(keys, k.String())
+		}
+		for _, b := range bindings.MouseButtons(a) {
+			keys = append(keys, b.String())
+		}
+		value := strings.Join(keys, ", ")
+		if s.waiting && i == s.selected {
+			value = "press a key (Escape cancels)"
+		}
+		lines = append(lines, s.marker(i)+fmt.Sprintf("%-14s %-28s %s", a, value, bindings.DescribeGamepad(a)))
+	}
+	lines = append(lines,
+		s.marker(s.profileRow())+"Profile: "+s.profiles.Active,
+		s.marker(s.mouseAimRow())+"Mouse aim: "+onOff(bindings.MouseAim()),
+		s.marker(s.backRow())+"Save and back",
+		"",
+	)
+	for _, c := range bindings.Conflicts() {
+		lines = append(lines, "! "+c.String())
+	}
+	if s.message != "" {
+		lines = append(lines, s.message)
+	}
+	ebitenutil.DebugPrint(screen, strings.Join(lines, "\n"))
+}
+
+func onOff(on bool) string {
+	if on {
+		return "on"
+	}
+	return "off"
+}
+
+func (s *controlsScene) marker(row int) string {
+	if row == s.selected {
+		return "> "
+	}
+	return "  "
+}
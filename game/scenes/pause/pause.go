@@ -0,0 +1,119 @@
+package pause
+
+import (
+	"image/color"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/ebitenutil"
+	"github.com/hajimehoshi/ebiten/v2/vector"
+
+	"github.com/co0p/tankismus/pkg/input"
+	"github.com/co0p/tankismus/pkg/scene"
+)
+
+// Item is an entry of the pause menu.
+type Item int
+
+const (
+	ItemResume Item = iota
+	ItemRestart
+	ItemSettings
+	ItemQuit
+)
+
+var itemLabels = [...]string{
+	ItemResume:   "Resume",
+	ItemRestart:  "Restart",
+	ItemSettings: "Settings",
+	ItemQuit:     "Quit to start",
+}
+
+var dimColor = color.RGBA{A: 160}
+
+// Actions connects the menu to the scene that opened it. The pause menu
+// cannot construct run or start scenes itself without an import cycle, so
+// the caller supplies what restarting and quitting mean.
+type Actions struct {
+	// Restart replaces the paused run with a fresh one.
+	Restart func()
+	// QuitToStart returns to the start screen.
+	QuitToStart func()
+}
+
+// Scene is the pause menu overlay. It is pushed over the run scene with
+// scene.Freeze, so the run keeps drawing but does not advance.
+type Scene struct {
+	manager  *scene.Manager
+	actions  Actions
+	selected Item
+}
+
+// New constructs a pause menu with Resume selected.
+func New(manager *scene.Manager, actions Actions) *Scene {
+	return &Scene{manager: manager, actions: actions}
+}
+
+func (s *Scene) OnEnter() {}
+
+func (s *Scene) OnExit() {}
+
+func (s *Scene) OnPause() {}
+
+func (s *Scene) OnResume() {}
+
+// Selected returns the highlighted menu item.
+func (s *Scene) Selected() Item {
+	return s.selected
+}
+
+// Update polls input itself, since the frozen run scene no longer does, and
+// handles menu navigation. Pause resumes the run directly. Only fresh presses
+// count, so the key that opened the menu does not immediately close it.
+func (s *Scene) Update(dt float64) {
+	_ = dt
+	input.Poll()
+
+	switch {
+	case input.IsActionJustPressed(input.ActionPause):
+		s.Activate(ItemResume)
+	case input.IsActionJustPressed(input.ActionMenuUp):
+		s.selected = (s.selected + Item(len(itemLabels)) - 1) % Item(len(itemLabels))
+	case input.IsActionJustPressed(input.ActionMenuDown):
+		s.selected = (s.selected + 1) % Item(len(itemLabels))
+	case input.IsActionJustPressed(input.ActionMenuConfirm):
+		s.Activate(s.selected)
+	}
+}
+
+// Activate performs a menu item as if it had been confirmed.
+func (s *Scene) Activate(item Item) {
+	switch item {
+	case ItemResume:
+		s.manager.Pop()
+	case ItemRestart:
+		if s.actions.Restart != nil {
+			s.actions.Restart()
+		}
+	case ItemSettings:
+		s.manager.Push(newControls(s.manager), scene.Cover)
+	case ItemQuit:
+		if s.actions.QuitToStart != nil {
+			s.actions.QuitToStart()
+		}
+	}
+}
+
+func (s *Scene) Draw(screen *ebiten.Image) {
+	size := screen.Bounds().Size()
+	vector.FillRect(screen, 0, 0, float32(size.X), float32(size.Y), dimColor, false)
+
+	x, y := size.X/2-60, size.Y/2-40
+	ebitenutil.DebugPrintAt(screen, "Paused", x, y)
+	for i, label := range itemLabels {
+		marker := "  "
+		if Item(i) == s.selected {
+			marker = "> "
+		}
+		ebitenutil.DebugPrintAt(screen, marker+label, x, y+24+i*16)
+	}
+}
@@ -0,0 +1,222 @@
+package pause
+
+import (
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/hajimehoshi/ebiten/v2"
+
+	"github.com/co0p/tankismus/pkg/input"
+	"github.com/co0p/tankismus/pkg/scene"
+)
+
+type stubScene struct{ updates int }
+
+func (s *stubScene) OnEnter()           {}
+func (s *stubScene) OnExit()            {}
+func (s *stubScene) Update(float64)     { s.updates++ }
+func (s *stubScene) Draw(*ebiten.Image) {}
+
+// press holds an action for one update and releases it for the next.
+func press(m *scene.Manager, tm *input.TestManager, a input.Action) {
+	tm.State[a] = true
+	m.Update(0.016)
+	tm.State[a] = false
+	m.Update(0.016)
+}
+
+func TestPauseMenu_NavigatesAndActivatesItems(t *testing.T) {
+	tm := input.NewTestManager()
+	input.SetManager(tm)
+	defer input.SetManager(nil)
+
+	restarted := false
+	game := &stubScene{}
+	m := scene.NewManager(game)
+	menu := New(m, Actions{Restart: func() { restarted = true }})
+	m.Push(menu, scene.Freeze)
+
+	press(m, tm, input.ActionMenuDown)
+	if menu.Selected() != ItemRestart {
+		t.Fatalf("Selected() = %v after moving down, want ItemRestart", menu.Selected())
+	}
+	press(m, tm, input.ActionMenuUp)
+	press(m, tm, input.ActionMenuUp)
+	if menu.Selected() != ItemQuit {
+		t.Fatalf("Selected() = %v after wrapping up, want ItemQuit", menu.Selected())
+	}
+	if game.updates != 0 {
+		t.Fatalf("frozen scene was updated %d times while paused", game.updates)
+	}
+
+	press(m, tm, input.ActionMenuDown)
+	press(m, tm, input.ActionMenuDown)
+	press(m, tm, input.ActionMenuConfirm)
+	if !restarted {
+		t.Fatalf("confirming Restart did not call Actions.Restart")
+	}
+}
+
+func TestPauseMenu_HeldPauseKeyDoesNotResumeImmediately(t *testing.T) {
+	tm := input.NewTestManager()
+	input.SetManager(tm)
+	defer input.SetManager(nil)
+
+	// The run scene polled the press that opens the menu.
+	m := scene.NewManager(&stubScene{})
+	tm.State[input.ActionPause] = true
+	tm.Poll()
+	m.Push(New(m, Actions{}), scene.Freeze)
+
+	m.Update(0.016)
+	if m.Len() != 2 {
+		t.Fatalf("pause menu closed while the key that opened it was still held")
+	}
+
+	tm.State[input.ActionPause] = false
+	m.Update(0.016)
+	press(m, tm, input.ActionPause)
+	if m.Len() != 1 {
+		t.Fatalf("pressing pause again did not resume; stack has %d scenes", m.Len())
+	}
+}
+
+// useTempProfiles points the controls screen at a file in a temporary
+// directory for the duration of the test.
+func useTempProfiles(t *testing.T) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "tankismus", "controls.json")
+	previous := profilePath
+	profilePath = func() (string, error) { return path, nil }
+	t.Cleanup(func() {
+		profilePath = previous
+		input.SetBindings(nil)
+	})
+	return path
+}
+
+func TestPauseMenu_SettingsCoversMenuAndReturns(t *testing.T) {
+	tm := input.NewTestManager()
+	input.SetManager(tm)
+	defer input.SetManager(nil)
+	path := useTempProfiles(t)
+
+	m := scene.NewManager(&stubScene{})
+	menu := New(m, Actions{})
+	m.Push(menu, scene.Freeze)
+
+	menu.Activate(ItemSettings)
+	if m.Len() != 3 || m.Current() == menu {
+		t.Fatalf("settings not pushed over the pause menu")
+	}
+	press(m, tm, input.ActionPause)
+	if m.Current() != menu {
+		t.Fatalf("leaving settings did not return to the pause menu")
+	}
+	if _, err := os.Stat(path); err != nil {
+		t.Fatalf("controls were not saved on leaving: %v", err)
+	}
+}
+
+func TestControls_RebindDetectsConflictsAndPersists(t *testing.T) {
+	path := useTempProfiles(t)
+	m := scene.NewManager(&stubScene{})
+	controls := newControls(m)
+	m.Push(controls, scene.Cover)
+
+	// Bind fire to D, which already turns right.
+	controls.selected = slices.Index(controls.actions, input.ActionFire)
+	controls.confirm()
+	if !controls.waiting {
+		t.Fatalf("confirming an action row must wait for a key")
+	}
+	controls.assign(ebiten.KeyD)
+	if !strings.Contains(controls.message, "conflict") {
+		t.Fatalf("message = %q, want a conflict warning", controls.message)
+	}
+	if keys := input.CurrentBindings().Keys(input.ActionFire); len(keys) != 1 || keys[0] != ebiten.KeyD {
+		t.Fatalf("live fire binding = %v, want [D]", keys)
+	}
+
+	// Escape cancels capturing and leaves the binding alone.
+	controls.confirm()
+	controls.assign(ebiten.KeyEscape)
+	if keys := input.CurrentBindings().Keys(input.ActionFire); keys[0] != ebiten.KeyD {
+		t.Fatalf("Escape changed the binding to %v", keys)
+	}
+
+	controls.leave()
+	saved, err := input.LoadProfiles(path)
+	if err != nil {
+		t.Fatalf("LoadProfiles: %v", err)
+	}
+	if keys := saved.Bindings().Keys(input.ActionFire); len(keys) != 1 || keys[0] != ebiten.KeyD {
+		t.Fatalf("saved fire binding = %v, want [D]", keys)
+	}
+}
+
+func TestControls_CyclesProfiles(t *testing.T) {
+	useTempProfiles(t)
+	m := scene.NewManager(&stubScene{})
+	controls := newControls(m)
+	m.Push(controls, scene.Cover)
+
+	controls.selected = controls.profileRow()
+	controls.confirm()
+	if controls.profiles.Active == input.DefaultProfile {
+		t.Fatalf("profile row did not switch away from the default profile")
+	}
+	if input.CurrentBindings() != controls.profiles.Bindings() {
+		t.Fatalf("switching profiles did not apply the new bindings")
+	}
+}
+
+func TestControls_TogglesMouseAim(t *testing.T) {
+	path := useTempProfiles(t)
+	m := scene.NewManager(&stubScene{})
+	controls := newControls(m)
+	m.Push(controls, scene.Cover)
+
+	controls.selected = controls.mouseAimRow()
+	controls.confirm()
+	if !input.CurrentBindings().MouseAim() {
+		t.Fatalf("mouse aim row did not enable mouse aim")
+	}
+
+	controls.leave()
+	saved, err := input.LoadProfiles(path)
+	if err != nil {
+		t.Fatalf("LoadProfiles: %v", err)
+	}
+	if !saved.Bindings().MouseAim() {
+		t.Fatalf("mouse aim setting was not saved")
+	}
+}
+
+func TestControls_CapturesKeysFromTheInputManager(t *testing.T) {
+	useTempProfiles(t)
+	tm := input.NewTestManager()
+	input.SetManager(tm)
+	defer input.SetManager(nil)
+
+	m := scene.NewManager(&stubScene{})
+	controls := newControls(m)
+	m.Push(controls, scene.Cover)
+	controls.selected = slices.Index(controls.actions, input.ActionFire)
+
+	press(m, tm, input.ActionMenuConfirm)
+	if !controls.waiting {
+		t.Fatalf("confirming an action row must wait for a key")
+	}
+	tm.Keys[ebiten.KeyF] = true
+	m.Update(0.016)
+	if controls.waiting {
+		t.Fatalf("a polled key press was not captured")
+	}
+	if keys := input.CurrentBindings().Keys(input.ActionFire); len(keys) != 1 || keys[0] != ebiten.KeyF {
+		t.Fatalf("live fire binding = %v, want [F]", keys)
+	}
+}
@@ -10,57 +10,98 @@ import (
 
 	"github.com/co0p/tankismus/game/assets"
 	"github.com/co0p/tankismus/game/components"
+	"github.com/co0p/tankismus/game/debug"
+	"github.com/co0p/tankismus/game/prefabs"
+	"github.com/co0p/tankismus/game/resources"
+	"github.com/co0p/tankismus/game/scenes/loading"
+	"github.com/co0p/tankismus/game/scenes/pause"
 	"github.com/co0p/tankismus/game/systems"
 	"github.com/co0p/tankismus/pkg/ecs"
 	"github.com/co0p/tankismus/pkg/input"
 	mappkg "github.com/co0p/tankismus/pkg/map"
+	"github.com/co0p/tankismus/pkg/scene"
 )
 
 // Scene represents the main gameplay scene.
 type Scene struct {
 	world      *ecs.World
+	scheduler  *ecs.Scheduler
+	inspector  *debug.Inspector
 	player     ecs.EntityID
 	tilemap    ecs.EntityID
 	levelMap   *mappkg.Map
 	lastUpdate time.Time
+
+	// ctx is kept to restart the run from the pause menu.
+	ctx Context
+}
+
+// Context configures a run scene. New and Load also accept a bare
+// *mappkg.Map, which behaves like a Context with only Map set; any other
+// value behaves like an empty Context.
+type Context struct {
+	// Manager is used to open the pause menu; nil disables pausing.
+	Manager *scene.Manager
+	// Map is the level map; nil loads game/assets/maps/map.json.
+	Map *mappkg.Map
+	// Start constructs the start scene for the pause menu's quit entry.
+	Start func() scene.Scene
+}
+
+// contextFrom normalizes the ctx argument of New and Load.
+func contextFrom(ctx interface{}) Context {
+	switch c := ctx.(type) {
+	case *Context:
+		if c != nil {
+			return *c
+		}
+	case *mappkg.Map:
+		return Context{Map: c}
+	}
+	return Context{}
 }
 
-// New constructs a new run scene with a single player tank.
-// If ctx is a *mappkg.Map, it is used as the level map (primarily for tests).
-// Otherwise, the scene attempts to load game/assets/maps/map.json. If loading
-// or validation fails, no level map or tilemap is created.
+// New constructs a new run scene with a single player tank spawned from the
+// player_tank prefab.
+// ctx is a *Context or, primarily for tests, a *mappkg.Map used as the
+// level map. Without a map, the scene attempts to load
+// game/assets/maps/map.json. If loading or validation fails, no level map or
+// tilemap is created.
 func New(ctx interface{}) *Scene {
-	w := ecs.NewWorld()
+	return Load(ctx, nil)
+}
+
+// Load prepares assets, the level map and the tilemap and constructs the run
+// scene like New. If report is non-nil it is called with the fraction of the
+// work done, from 0 to 1, and a short status line. Load only shares the
+// assets registry with the rest of the game, so it may run on a background
+// goroutine, for example from the loading scene.
+func Load(ctx interface{}, report func(fraction float64, status string)) *Scene {
+	if report == nil {
+		report = func(float64, string) {}
+	}
+
 	// Ensure core assets, including tile sprites, are loaded before composing
 	// the level tilemap. Load is idempotent.
-	_ = assets.Load()
-
-	// Determine the level map to use.
-	var levelMap *mappkg.Map
-	if m, ok := ctx.(*mappkg.Map); ok && m != nil {
-		levelMap = m
-	} else {
-		// Attempt to load the default JSON map.
-		const defaultMapPath = "game/assets/maps/map.json"
-		file, err := os.Open(defaultMapPath)
-		if err == nil {
-			defer file.Close()
-			dec := json.NewDecoder(file)
-			var loaded mappkg.Map
-			if err := dec.Decode(&loaded); err == nil {
-				// Ensure the loaded map satisfies basic invariants.
-				if err := loaded.ValidateForGenerator(); err == nil {
-					levelMap = &loaded
-				}
-			}
-		}
-	}
+	report(0, "loading assets")
+	_ = assets.LoadWithProgress(func(done, total int) {
+		report(0.6*float64(done)/float64(total), "loading assets")
+	})
+
+	report(0.6, "loading level")
+	c := contextFrom(ctx)
+	levelMap := loadLevelMap(c.Map)
+
+	w := ecs.NewWorld()
+	level := &resources.Level{Map: levelMap, TileSize: resources.DefaultTileSize}
+	ecs.SetResource(w, level)
+	ecs.SetResource(w, &resources.Camera{})
 
+	report(0.7, "composing tilemap")
 	var tilemapEntity ecs.EntityID
 	if levelMap != nil {
-		const tileSize = 16
 		// Compose the tilemap image and register it in the assets registry.
-		if img, err := assets.ComposeTilemap("tilemap_ground", levelMap, tileSize); err == nil {
+		if img, err := assets.ComposeTilemap("tilemap_ground", levelMap, level.TileSize); err == nil {
 			wImg, hImg := img.Size()
 			tilemapEntity = w.NewEntity()
 			// Position the tilemap so that its top-left corner aligns with the
@@ -72,48 +113,157 @@ func New(ctx interface{}) *Scene {
 		}
 	}
 
-	player := w.NewEntity()
-	w.AddComponent(player, &components.Transform{X: 100, Y: 100, Rotation: 0, Scale: 1})
-	w.AddComponent(player, &components.Velocity{})
-	w.AddComponent(player, &components.ControlIntent{})
-	w.AddComponent(player, &components.MovementParams{
-		MaxForwardSpeed:     133.3333,
-		MaxBackwardSpeed:    80,
-		LinearAcceleration:  200,
-		LinearDeceleration:  300,
-		MaxTurnRate:         3,
-		AngularAcceleration: 6,
-		AngularDeceleration: 9,
-	})
-	w.AddComponent(player, &components.Sprite{SpriteID: "player_tank"})
-	w.AddComponent(player, &components.RenderOrder{Z: 10})
+	report(0.9, "spawning entities")
+	player, err := prefabs.Library().Spawn(w, prefabs.PlayerTank)
+	if err != nil {
+		panic(err)
+	}
+	if t, ok := ecs.Get[*components.Transform](w, player); ok {
+		t.X, t.Y = 100, 100
+	}
 
+	report(1, "ready")
 	return &Scene{
 		world:      w,
+		scheduler:  newScheduler(player),
+		inspector:  debug.NewInspector(),
 		player:     player,
 		tilemap:    tilemapEntity,
 		levelMap:   levelMap,
 		lastUpdate: time.Now(),
+		ctx:        c,
+	}
+}
+
+// loadLevelMap returns m if it is non-nil and otherwise decodes and
+// validates the default JSON map. It returns nil if that fails.
+func loadLevelMap(m *mappkg.Map) *mappkg.Map {
+	if m != nil {
+		return m
+	}
+
+	const defaultMapPath = "game/assets/maps/map.json"
+	file, err := os.Open(defaultMapPath)
+	if err != nil {
+		return nil
+	}
+	defer file.Close()
+	var loaded mappkg.Map
+	if err := json.NewDecoder(file).Decode(&loaded); err != nil {
+		return nil
 	}
+	// Ensure the loaded map satisfies basic invariants.
+	if err := loaded.ValidateForGenerator(); err != nil {
+		return nil
+	}
+	return &loaded
+}
+
+// newScheduler registers the gameplay systems run by the scene each frame.
+func newScheduler(player ecs.EntityID) *ecs.Scheduler {
+	sched := ecs.NewScheduler()
+	sched.SetParallel(true)
+	for _, sys := range []ecs.System{
+		{
+			Name:  "input.poll",
+			Stage: ecs.StageInput,
+			Run:   func(*ecs.World, float64) { input.Poll() },
+		},
+		{
+			Name:   "input.movement",
+			Stage:  ecs.StageInput,
+			After:  []string{"input.poll"},
+			Writes: []ecs.ComponentType{components.TypeControlIntent},
+			Run:    func(w *ecs.World, _ float64) { systems.InputMovementSystem(w, player) },
+		},
+		{
+			Name:   "input.mouse_aim",
+			Stage:  ecs.StageInput,
+			After:  []string{"input.movement"},
+			Reads:  []ecs.ComponentType{components.TypeTransform},
+			Writes: []ecs.ComponentType{components.TypeControlIntent},
+			// The manager reports the scheme, so replays use the recorded one.
+			Run: func(w *ecs.World, _ float64) {
+				if input.MouseAim() {
+					systems.MouseAimSystem(w, player)
+				}
+			},
+		},
+		{
+			Name:   "movement",
+			Stage:  ecs.StageSimulation,
+			Reads:  []ecs.ComponentType{components.TypeControlIntent, components.TypeMovementParams},
+			Writes: []ecs.ComponentType{components.TypeTransform, components.TypeVelocity},
+			Run:    systems.MovementSystem,
+		},
+		{
+			Name:   "transform.propagate",
+			Stage:  ecs.StagePostPhysics,
+			Reads:  []ecs.ComponentType{components.TypeLocalTransform},
+			Writes: []ecs.ComponentType{components.TypeTransform},
+			Run:    func(w *ecs.World, _ float64) { systems.TransformPropagationSystem(w) },
+		},
+	} {
+		if err := sched.Add(sys); err != nil {
+			panic(err)
+		}
+	}
+	if err := sched.Build(); err != nil {
+		panic(err)
+	}
+	return sched
 }
 
 func (s *Scene) OnEnter() {}
 
-func (s *Scene) OnExit() {}
+// OnExit hands the mouse back to gameplay actions in case the inspector was
+// left open.
+func (s *Scene) OnExit() {
+	input.SuppressMouseActions(false)
+}
+
+// OnPause is called when the pause menu opens. The scene manager stops
+// updating the run while it is frozen, so no simulation time passes.
+func (s *Scene) OnPause() {}
+
+func (s *Scene) OnResume() {}
 
 func (s *Scene) Update(dt float64) {
-	input.Poll()
-	systems.InputMovementSystem(s.world, s.player)
-	systems.MovementSystem(s.world, dt)
+	s.scheduler.Run(s.world, dt)
+	// After the input stage, so the inspector sees this frame's poll.
+	s.inspector.Update(s.world)
+
+	if input.IsActionJustPressed(input.ActionPause) {
+		s.openPauseMenu()
+	}
+}
+
+// openPauseMenu freezes the run under the pause menu.
+func (s *Scene) openPauseMenu() {
+	m := s.ctx.Manager
+	if m == nil {
+		return
+	}
+	m.Push(pause.New(m, pause.Actions{
+		Restart: func() {
+			ctx := s.ctx
+			m.Transition(loading.New(m, func(report loading.Reporter) scene.Scene {
+				return Load(&ctx, report)
+			}, ctx.Start), scene.Fade{Duration: 0.3})
+		},
+		QuitToStart: func() {
+			if s.ctx.Start != nil {
+				m.Transition(s.ctx.Start(), scene.Fade{Duration: 0.3})
+			}
+		},
+	}), scene.Freeze)
 }
 
 func (s *Scene) Draw(screen *ebiten.Image) {
 	screen.Fill(color.RGBA{R: 10, G: 40, B: 10, A: 255})
 
-	// ensure assets are loaded; Load is idempotent.
-	_ = assets.Load()
-
 	systems.RenderSystem(s.world, screen)
+	s.inspector.Draw(s.world, screen)
 }
 
 // World exposes the underlying ECS world for testing purposes.
@@ -121,6 +271,17 @@ func (s *Scene) World() *ecs.World {
 	return s.world
 }
 
+// Scheduler exposes the system scheduler, for example to toggle systems or
+// read per-system timings while debugging.
+func (s *Scene) Scheduler() *ecs.Scheduler {
+	return s.scheduler
+}
+
+// Inspector returns the ECS inspector overlay, toggled in game with F1.
+func (s *Scene) Inspector() *debug.Inspector {
+	return s.inspector
+}
+
 // Player returns the player entity ID for testing purposes.
 func (s *Scene) Player() ecs.EntityID {
 	return s.player
@@ -2,13 +2,17 @@ package run
 
 import (
 	"image/color"
+	"math"
+	"path/filepath"
 	"testing"
 
 	"github.com/co0p/tankismus/game/assets"
 	"github.com/co0p/tankismus/game/components"
+	"github.com/co0p/tankismus/game/resources"
 	"github.com/co0p/tankismus/pkg/ecs"
 	"github.com/co0p/tankismus/pkg/input"
 	mappkg "github.com/co0p/tankismus/pkg/map"
+	"github.com/co0p/tankismus/pkg/scene"
 	"github.com/hajimehoshi/ebiten/v2"
 )
 
@@ -135,3 +139,288 @@ func TestNewRunScene_HasMapAndTilemapEntity(t *testing.T) {
 		}
 	}
 }
+
+func TestNewRunScene_ExposesLevelAsWorldResource(t *testing.T) {
+	levelMap := newTestLevelMap(t)
+	s := New(levelMap)
+
+	level, ok := ecs.GetResource[*resources.Level](s.World())
+	if !ok {
+		t.Fatalf("expected level resource in the run scene world")
+	}
+	if level.Map != levelMap {
+		t.Fatalf("level resource map = %p, want %p", level.Map, levelMap)
+	}
+	if level.TileSize != resources.DefaultTileSize {
+		t.Fatalf("level tile size = %d, want %d", level.TileSize, resources.DefaultTileSize)
+	}
+}
+
+func TestNewRunScene_SpawnsPlayerFromPrefab(t *testing.T) {
+	s := New(newTestLevelMap(t))
+	world := s.World()
+	player := s.Player()
+
+	if !world.HasComponent(player, components.TypePlayerTag) {
+		t.Fatalf("player spawned without the player_tank prefab's PlayerTag")
+	}
+	tr, ok := ecs.Get[*components.Transform](world, player)
+	if !ok || tr.X != 100 || tr.Y != 100 || tr.Scale != 1 {
+		t.Fatalf("player transform = %+v, want spawn position (100,100) at unit scale", tr)
+	}
+	mp, _ := ecs.Get[*components.MovementParams](world, player)
+	if mp.MaxForwardSpeed <= 0 {
+		t.Fatalf("player movement params not loaded from prefab: %+v", mp)
+	}
+}
+
+func TestLoad_ReportsProgressUpToCompletion(t *testing.T) {
+	var fractions []float64
+	s := Load(newTestLevelMap(t), func(fraction float64, status string) {
+		if status == "" {
+			t.Fatalf("progress reported without a status at %v", fraction)
+		}
+		fractions = append(fractions, fraction)
+	})
+	if s == nil || !s.World().IsAlive(s.Player()) {
+		t.Fatalf("Load did not construct a scene with a player")
+	}
+
+	if len(fractions) < 2 || fractions[len(fractions)-1] != 1 {
+		t.Fatalf("progress = %v, want several reports ending at 1", fractions)
+	}
+	for i := 1; i < len(fractions); i++ {
+		if fractions[i] < fractions[i-1] {
+			t.Fatalf("progress went backwards: %v", fractions)
+		}
+	}
+}
+
+func TestRunScene_PauseFreezesSimulation(t *testing.T) {
+	testMgr := input.NewTestManager()
+	input.SetManager(testMgr)
+	defer input.SetManager(nil)
+
+	manager := scene.NewManager(nil)
+	s := New(&Context{Manager: manager, Map: newTestLevelMap(t)})
+	manager.SetScene(s)
+	tr, _ := ecs.Get[*components.Transform](s.World(), s.Player())
+
+	testMgr.State[input.ActionMoveForward] = true
+	testMgr.State[input.ActionPause] = true
+	manager.Update(0.1)
+	if manager.Len() != 2 {
+		t.Fatalf("pressing pause did not open the pause menu")
+	}
+
+	x := tr.X
+	for i := 0; i < 10; i++ {
+		manager.Update(0.1)
+	}
+	if tr.X != x {
+		t.Fatalf("player moved from %v to %v while paused", x, tr.X)
+	}
+
+	// Release pause and press it again to resume.
+	testMgr.State[input.ActionPause] = false
+	manager.Update(0.1)
+	testMgr.State[input.ActionPause] = true
+	manager.Update(0.1)
+	if manager.Len() != 1 || manager.Current() != s {
+		t.Fatalf("pause menu did not close")
+	}
+	manager.Update(0.1)
+	if manager.Len() != 1 {
+		t.Fatalf("held pause key reopened the menu right after resuming")
+	}
+}
+
+// playScript updates a fresh run scene through the scene manager for the
+// given number of frames, the way the game loop does, calling before ahead of
+// each frame. It returns the player's final transform.
+func playScript(t *testing.T, frames int, dt func(int) float64, before func(int)) components.Transform {
+	t.Helper()
+	manager := scene.NewManager(nil)
+	s := New(&Context{Manager: manager, Map: newTestLevelMap(t)})
+	manager.SetScene(s)
+	for i := 0; i < frames; i++ {
+		before(i)
+		manager.Update(input.BeginFrame(dt(i)))
+	}
+	tr, _ := ecs.Get[*components.Transform](s.World(), s.Player())
+	return *tr
+}
+
+func TestRunScene_ReplaysRecordedInput(t *testing.T) {
+	defer input.SetManager(nil)
+
+	// Record a session: drive forward, turn while slowing down, then pause
+	// and resume. Frame times vary like a real game loop.
+	tm := input.NewTestManager()
+	recorder := input.NewRecorder(tm)
+	input.SetManager(recorder)
+	const frames = 90
+	frameTime := func(i int) float64 { return 0.014 + float64(i%3)*0.002 }
+	recorded := playScript(t, frames, frameTime, func(i int) {
+		tm.State[input.ActionMoveForward] = i < 60
+		tm.Values[input.ActionTurnRight] = 0
+		if i >= 30 && i < 50 {
+			tm.Values[input.ActionTurnRight] = 0.6
+		}
+		tm.State[input.ActionPause] = i == 70 || i == 75
+	})
+
+	path := filepath.Join(t.TempDir(), "session.json")
+	if err := recorder.Recording().Save(path); err != nil {
+		t.Fatalf("Save: %v", err)
+	}
+	rec, err := input.LoadRecording(path)
+	if err != nil {
+		t.Fatalf("LoadRecording: %v", err)
+	}
+
+	// Replay with a different measured frame time; the recorded one wins.
+	input.SetManager(input.NewPlayback(rec))
+	replayed := playScript(t, frames, func(int) float64 { return 1 }, func(int) {})
+
+	if replayed != recorded {
+		t.Fatalf("replayed player transform %+v, recorded %+v", replayed, recorded)
+	}
+	if recorded.X == 100 && recorded.Y == 100 {
+		t.Fatalf("recorded session did not move the player")
+	}
+}
+
+func TestRunScene_MouseAimTurnsPlayerTowardsCursor(t *testing.T) {
+	testMgr := input.NewTestManager()
+	input.SetManager(testMgr)
+	defer input.SetManager(nil)
+	defer input.SetBindings(nil)
+
+	s := New(newTestLevelMap(t))
+	tr, _ := ecs.Get[*components.Transform](s.World(), s.Player())
+	tr.Rotation = 0
+	// Cursor straight below the tank: a quarter turn clockwise.
+	testMgr.CursorX, testMgr.CursorY = tr.X, tr.Y+200
+
+	s.Update(0.016)
+	if tr.Rotation != 0 {
+		t.Fatalf("player turned to %v with mouse aim off", tr.Rotation)
+	}
+
+	bindings := input.DefaultBindings()
+	bindings.SetMouseAim(true)
+	input.SetBindings(bindings)
+	for i := 0; i < 120; i++ {
+		s.Update(0.016)
+	}
+	if math.Abs(tr.Rotation-math.Pi/2) > 0.05 {
+		t.Fatalf("player rotation = %v after aiming, want about pi/2", tr.Rotation)
+	}
+}
+
+func TestRunScene_ReplayUsesRecordedMouseAim(t *testing.T) {
+	defer input.SetManager(nil)
+	defer input.SetBindings(nil)
+
+	// Record with mouse aim on and the cursor below the start position.
+	bindings := input.DefaultBindings()
+	bindings.SetMouseAim(true)
+	input.SetBindings(bindings)
+	tm := input.NewTestManager()
+	tm.CursorX, tm.CursorY = 100, 300
+	recorder := input.NewRecorder(tm)
+	input.SetManager(recorder)
+	const frames = 60
+	frameTime := func(int) float64 { return 0.016 }
+	recorded := playScript(t, frames, frameTime, func(int) {})
+
+	// Replay on a machine whose controls have mouse aim off.
+	input.SetBindings(nil)
+	input.SetManager(input.NewPlayback(recorder.Recording()))
+	replayed := playScript(t, frames, frameTime, func(int) {})
+
+	if replayed != recorded {
+		t.Fatalf("replayed player transform %+v, recorded %+v", replayed, recorded)
+	}
+	if recorded.Rotation == 0 {
+		t.Fatalf("recorded session with mouse aim did not turn the player")
+	}
+}
+
+func TestRunScene_InspectorTakesClicksThroughTheManager(t *testing.T) {
+	testMgr := input.NewTestManager()
+	input.SetManager(testMgr)
+	defer input.SetManager(nil)
+
+	s := New(newTestLevelMap(t))
+	defer s.OnExit()
+	tr, _ := ecs.Get[*components.Transform](s.World(), s.Player())
+	// Scroll the camera so screen and world positions differ.
+	ecs.SetResource(s.World(), &resources.Camera{X: 40, Y: 30})
+	testMgr.CursorX, testMgr.CursorY = tr.X-40, tr.Y-30
+
+	left := input.MouseButton(ebiten.MouseButtonLeft)
+	testMgr.State[input.ActionDebugToggle] = true
+	s.Update(0.016)
+	testMgr.State[input.ActionDebugToggle] = false
+	if !s.Inspector().Visible() {
+		t.Fatalf("debug toggle action did not open the inspector")
+	}
+	testMgr.Mouse[left] = true
+	s.Update(0.016)
+	if input.IsActionDown(input.ActionFire) {
+		t.Fatalf("clicking with the inspector open fired")
+	}
+	if got, ok := s.Inspector().Selected(); !ok || got != s.Player() {
+		t.Fatalf("inspector selected %v, %v; want the player %v under the cursor", got, ok, s.Player())
+	}
+
+	testMgr.State[input.ActionDebugToggle] = true
+	testMgr.Mouse[left] = false
+	s.Update(0.016)
+	testMgr.State[input.ActionDebugToggle] = false
+	testMgr.Mouse[left] = true
+	s.Update(0.016)
+	if !input.IsActionDown(input.ActionFire) {
+		t.Fatalf("left click did not fire with the inspector closed")
+	}
+}
+
+func TestRunScene_ReplaysInspectorSelection(t *testing.T) {
+	defer input.SetManager(nil)
+
+	tm := input.NewTestManager()
+	recorder := input.NewRecorder(tm)
+	input.SetManager(recorder)
+	left := input.MouseButton(ebiten.MouseButtonLeft)
+	script := func(i int) {
+		tm.State[input.ActionDebugToggle] = i == 0
+		tm.Mouse[left] = i == 2
+		tm.CursorX, tm.CursorY = 100, 100
+	}
+	play := func(before func(int)) *Scene {
+		s := New(newTestLevelMap(t))
+		defer s.OnExit()
+		for i := 0; i < 4; i++ {
+			before(i)
+			s.Update(0.016)
+		}
+		return s
+	}
+	recorded := play(script)
+	if _, ok := recorded.Inspector().Selected(); !ok {
+		t.Fatalf("recorded session did not select an entity")
+	}
+
+	input.SetManager(input.NewPlayback(recorder.Recording()))
+	replayed := play(func(int) {})
+	if !replayed.Inspector().Visible() {
+		t.Fatalf("replay did not open the inspector")
+	}
+	got, _ := replayed.Inspector().Selected()
+	want, _ := recorded.Inspector().Selected()
+	if got != want {
+		t.Fatalf("replay selected %v, recorded %v", got, want)
+	}
+}
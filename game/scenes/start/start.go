@@ -6,6 +6,7 @@ import (
 	"github.com/hajimehoshi/ebiten/v2"
 	"github.com/hajimehoshi/ebiten/v2/ebitenutil"
 
+	"github.com/co0p/tankismus/game/scenes/loading"
 	"github.com/co0p/tankismus/game/scenes/run"
 	"github.com/co0p/tankismus/pkg/input"
 	"github.com/co0p/tankismus/pkg/scene"
@@ -29,7 +30,12 @@ func (s *Scene) Update(dt float64) {
 	_ = dt
 	// Any key press starts the game.
 	if input.AnyKeyPressed() {
-		s.manager.SetScene(run.New(s.manager))
+		s.manager.Transition(loading.New(s.manager, func(report loading.Reporter) scene.Scene {
+			return run.Load(&run.Context{
+				Manager: s.manager,
+				Start:   func() scene.Scene { return New(s.manager) },
+			}, report)
+		}, func() scene.Scene { return New(s.manager) }), scene.Fade{Duration: 0.3})
 	}
 }
 
@@ -20,35 +20,14 @@ func InputMovementSystem(world *ecs.World, player ecs.EntityID) {
 		return
 	}
 
-	// Throttle: forward/backward along facing direction.
-	throttle := 0.0
-	if input.IsActionDown(input.ActionMoveForward) {
-		throttle += 1
-	}
-	if input.IsActionDown(input.ActionMoveBackward) {
-		throttle -= 1
-	}
-	if throttle > 1 {
-		throttle = 1
-	}
-	if throttle < -1 {
-		throttle = -1
-	}
+	// Throttle: forward/backward along facing direction. Analog sticks and
+	// triggers yield partial values; keys yield full throttle.
+	throttle := input.ActionValue(input.ActionMoveForward) - input.ActionValue(input.ActionMoveBackward)
+	throttle = max(-1, min(1, throttle))
 
 	// Turn: left/right.
-	turn := 0.0
-	if input.IsActionDown(input.ActionTurnLeft) {
-		turn -= 1
-	}
-	if input.IsActionDown(input.ActionTurnRight) {
-		turn += 1
-	}
-	if turn > 1 {
-		turn = 1
-	}
-	if turn < -1 {
-		turn = -1
-	}
+	turn := input.ActionValue(input.ActionTurnRight) - input.ActionValue(input.ActionTurnLeft)
+	turn = max(-1, min(1, turn))
 
 	intent.Throttle = throttle
 	intent.Turn = turn
@@ -93,6 +93,29 @@ func TestInputMovementSystem_SetsTurnFromTurnKeys(t *testing.T) {
 	}
 }
 
+func TestInputMovementSystem_UsesAnalogValues(t *testing.T) {
+	w, id := newInputTestWorld()
+	manager := input.NewTestManager()
+	input.SetManager(manager)
+
+	// Half a trigger forward and a slight stick push left.
+	manager.Values[input.ActionMoveForward] = 0.5
+	manager.Values[input.ActionTurnLeft] = 0.25
+	InputMovementSystem(w, id)
+	cI, _ := w.GetComponent(id, components.TypeControlIntent)
+	intent := cI.(*components.ControlIntent)
+	if intent.Throttle != 0.5 || intent.Turn != -0.25 {
+		t.Fatalf("expected throttle=0.5 turn=-0.25, got throttle=%v turn=%v", intent.Throttle, intent.Turn)
+	}
+
+	// A key held with an opposing analog input cancels out partially.
+	manager.State[input.ActionMoveBackward] = true
+	InputMovementSystem(w, id)
+	if intent.Throttle != -0.5 {
+		t.Fatalf("expected throttle=-0.5, got %v", intent.Throttle)
+	}
+}
+
 func TestInputMovementSystem_DoesNotModifyVelocityDirectly(t *testing.T) {
 	w, id := newInputTestWorld()
 	manager := input.NewTestManager()
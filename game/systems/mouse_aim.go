@@ -0,0 +1,57 @@
+package systems
+
+import (
+	"math"
+
+	"github.com/co0p/tankismus/game/components"
+	"github.com/co0p/tankismus/game/resources"
+	"github.com/co0p/tankismus/pkg/ecs"
+	"github.com/co0p/tankismus/pkg/input"
+)
+
+const (
+	// aimFullTurnAngle is the heading error in radians at and above which
+	// mouse aim turns at full rate. Smaller errors turn proportionally so the
+	// tank settles on the cursor instead of oscillating around it.
+	aimFullTurnAngle = math.Pi / 6
+	// aimTolerance is the heading error in radians treated as on target.
+	aimTolerance = 0.01
+)
+
+// CursorWorldPosition returns the mouse cursor position in world
+// coordinates, using the world's Camera resource when present.
+func CursorWorldPosition(world *ecs.World) (x, y float64) {
+	x, y = input.CursorPosition()
+	if cam, ok := ecs.GetResource[*resources.Camera](world); ok {
+		return cam.ScreenToWorld(x, y)
+	}
+	return x, y
+}
+
+// MouseAimSystem implements the mouse aim control scheme: it sets the
+// player's ControlIntent.Turn so the tank rotates towards the cursor. It runs
+// after InputMovementSystem and replaces the turn from the turn actions,
+// keeping the throttle.
+func MouseAimSystem(world *ecs.World, player ecs.EntityID) {
+	t, okT := ecs.Get[*components.Transform](world, player)
+	intent, okI := ecs.Get[*components.ControlIntent](world, player)
+	if !okT || !okI {
+		return
+	}
+
+	cx, cy := CursorWorldPosition(world)
+	dx, dy := cx-t.X, cy-t.Y
+	if dx == 0 && dy == 0 {
+		intent.Turn = 0
+		return
+	}
+
+	// Heading error in [-pi, pi]; positive means the cursor lies clockwise
+	// of the facing direction, which is a positive turn on screen.
+	diff := math.Remainder(math.Atan2(dy, dx)-t.Rotation, 2*math.Pi)
+	if math.Abs(diff) < aimTolerance {
+		intent.Turn = 0
+		return
+	}
+	intent.Turn = clamp(diff/aimFullTurnAngle, -1, 1)
+}
@@ -0,0 +1,61 @@
+package systems
+
+import (
+	"math"
+	"testing"
+
+	"github.com/co0p/tankismus/game/components"
+	"github.com/co0p/tankismus/game/resources"
+	"github.com/co0p/tankismus/pkg/ecs"
+	"github.com/co0p/tankismus/pkg/input"
+)
+
+func TestMouseAimSystem_TurnsTowardsCursor(t *testing.T) {
+	w, id := newInputTestWorld()
+	manager := input.NewTestManager()
+	input.SetManager(manager)
+	defer input.SetManager(nil)
+
+	tr, _ := ecs.Get[*components.Transform](w, id)
+	intent, _ := ecs.Get[*components.ControlIntent](w, id)
+	tr.X, tr.Y, tr.Rotation = 100, 100, 0
+	intent.Throttle = 1
+
+	tests := []struct {
+		name             string
+		cursorX, cursorY float64
+		want             float64
+	}{
+		{"ahead", 200, 100, 0},
+		{"below turns clockwise", 100, 200, 1},
+		{"above turns counter-clockwise", 100, 0, -1},
+		{"behind takes the short way", 0, 99, -1},
+		{"slightly off turns proportionally", 200, 100 + 100*math.Tan(math.Pi/12), 0.5},
+	}
+	for _, tt := range tests {
+		manager.CursorX, manager.CursorY = tt.cursorX, tt.cursorY
+		MouseAimSystem(w, id)
+		if math.Abs(intent.Turn-tt.want) > 1e-9 {
+			t.Errorf("%s: Turn = %v, want %v", tt.name, intent.Turn, tt.want)
+		}
+		if intent.Throttle != 1 {
+			t.Fatalf("%s: mouse aim changed throttle to %v", tt.name, intent.Throttle)
+		}
+	}
+}
+
+func TestCursorWorldPosition_AppliesCamera(t *testing.T) {
+	manager := input.NewTestManager()
+	input.SetManager(manager)
+	defer input.SetManager(nil)
+	manager.CursorX, manager.CursorY = 10, 20
+
+	w := ecs.NewWorld()
+	if x, y := CursorWorldPosition(w); x != 10 || y != 20 {
+		t.Fatalf("without camera = (%v, %v), want screen position (10, 20)", x, y)
+	}
+	ecs.SetResource(w, &resources.Camera{X: 300, Y: -50})
+	if x, y := CursorWorldPosition(w); x != 310 || y != -30 {
+		t.Fatalf("with camera = (%v, %v), want (310, -30)", x, y)
+	}
+}
@@ -9,34 +9,20 @@ import (
 
 // MovementSystem updates velocity based on control intent and movement
 // parameters and then applies velocity to transform for all
-// entities that participate in the tank movement model.
+// entities that participate in the tank movement model. Transforms and
+// velocities that actually changed are marked for change detection.
 func MovementSystem(world *ecs.World, dt float64) {
-	required := ecs.MaskFor(
-		components.TypeTransform,
-		components.TypeVelocity,
-		components.TypeControlIntent,
-		components.TypeMovementParams,
-	)
-	entities := world.Find(required)
-	for _, id := range entities {
-		cT, okT := world.GetComponent(id, components.TypeTransform)
-		cV, okV := world.GetComponent(id, components.TypeVelocity)
-		cIntent, okCI := world.GetComponent(id, components.TypeControlIntent)
-		cParams, okMP := world.GetComponent(id, components.TypeMovementParams)
-		if !okT || !okV || !okCI || !okMP {
-			panic("MovementSystem: entity missing required movement components")
-		}
-
-		p, okP := cT.(*components.Transform)
-		v, okVel := cV.(*components.Velocity)
-		intent, okI := cIntent.(*components.ControlIntent)
-		params, okM := cParams.(*components.MovementParams)
-		if !okP || !okVel || !okI || !okM {
-			panic("MovementSystem: component type assertion failed")
-		}
-
-		applyMovementModel(p, v, intent, params, dt)
-	}
+	ecs.NewQuery4[*components.Transform, *components.Velocity, *components.ControlIntent, *components.MovementParams](world).
+		Each(func(id ecs.EntityID, p *components.Transform, v *components.Velocity, intent *components.ControlIntent, params *components.MovementParams) {
+			prevP, prevV := *p, *v
+			applyMovementModel(p, v, intent, params, dt)
+			if *p != prevP {
+				world.MarkChanged(id, components.TypeTransform)
+			}
+			if *v != prevV {
+				world.MarkChanged(id, components.TypeVelocity)
+			}
+		})
 }
 
 func applyMovementModel(p *components.Transform, v *components.Velocity, intent *components.ControlIntent, params *components.MovementParams, dt float64) {
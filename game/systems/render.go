@@ -17,49 +17,86 @@ type drawable struct {
 	z         int
 }
 
+// drawOrder caches the sorted render order between frames. It is stored as
+// a world resource and only rebuilt when drawables were added or removed or a
+// RenderOrder changed, so moving sprites around does not trigger a re-sort.
+type drawOrder struct {
+	// builtAt is the tick the order was last built or found up to date.
+	builtAt  uint64
+	entities []ecs.EntityID
+}
+
+// stale reports whether the cached order may no longer match the world.
+func (o *drawOrder) stale(world *ecs.World) bool {
+	if o.entities == nil || o.builtAt+1 < world.Tick() {
+		// Never built, or built so long ago that removal logs have expired.
+		return true
+	}
+	for _, t := range []ecs.ComponentType{components.TypeTransform, components.TypeSprite, components.TypeRenderOrder} {
+		if len(world.AddedSince(t, o.builtAt)) > 0 || len(world.RemovedSince(t, o.builtAt)) > 0 {
+			return true
+		}
+	}
+	return len(world.ChangedSince(components.TypeRenderOrder, o.builtAt)) > 0
+}
+
+// renderZ returns the render layer of an entity, defaulting to zero.
+func renderZ(world *ecs.World, id ecs.EntityID) int {
+	if ro, ok := ecs.Get[*components.RenderOrder](world, id); ok {
+		return ro.Z
+	}
+	return 0
+}
+
 // collectDrawables finds all entities with Transform and Sprite components,
 // attaches an optional RenderOrder (defaulting to zero when absent), and
 // returns them sorted by increasing z (and entity ID as a stable tiebreaker).
+//
+// The sorted order is cached in the world and reused while no drawables were
+// added or removed and no RenderOrder was changed (see ecs.World.MarkChanged).
 func collectDrawables(world *ecs.World) []drawable {
-	required := ecs.MaskFor(components.TypeTransform, components.TypeSprite)
-	entities := world.Find(required)
-	drawables := make([]drawable, 0, len(entities))
+	order, ok := ecs.GetResource[*drawOrder](world)
+	if !ok {
+		order = &drawOrder{}
+		ecs.SetResource(world, order)
+	}
 
-	for _, id := range entities {
-		cT, okT := world.GetComponent(id, components.TypeTransform)
-		cS, okS := world.GetComponent(id, components.TypeSprite)
-		if !okT || !okS {
-			continue
+	if order.stale(world) {
+		entities := make([]ecs.EntityID, 0)
+		ecs.NewQuery2[*components.Transform, *components.Sprite](world).
+			Each(func(id ecs.EntityID, _ *components.Transform, _ *components.Sprite) {
+				entities = append(entities, id)
+			})
+		z := make(map[ecs.EntityID]int, len(entities))
+		for _, id := range entities {
+			z[id] = renderZ(world, id)
 		}
+		sort.Slice(entities, func(i, j int) bool {
+			if z[entities[i]] == z[entities[j]] {
+				return entities[i] < entities[j]
+			}
+			return z[entities[i]] < z[entities[j]]
+		})
+		order.entities = entities
+	}
+	// Changes later in this tick carry the same stamp, so the next check
+	// still sees them.
+	order.builtAt = world.Tick()
 
-		p, okP := cT.(*components.Transform)
-		s, okSprite := cS.(*components.Sprite)
-		if !okP || !okSprite {
+	drawables := make([]drawable, 0, len(order.entities))
+	for _, id := range order.entities {
+		p, okT := ecs.Get[*components.Transform](world, id)
+		s, okS := ecs.Get[*components.Sprite](world, id)
+		if !okT || !okS {
 			continue
 		}
-
-		z := 0
-		if cZ, okZ := world.GetComponent(id, components.TypeRenderOrder); okZ {
-			if ro, okRO := cZ.(*components.RenderOrder); okRO {
-				z = ro.Z
-			}
-		}
-
 		drawables = append(drawables, drawable{
 			entity:    id,
 			transform: p,
 			sprite:    s,
-			z:         z,
+			z:         renderZ(world, id),
 		})
 	}
-
-	sort.Slice(drawables, func(i, j int) bool {
-		if drawables[i].z == drawables[j].z {
-			return drawables[i].entity < drawables[j].entity
-		}
-		return drawables[i].z < drawables[j].z
-	})
-
 	return drawables
 }
 
@@ -92,3 +92,67 @@ func TestCollectDrawablesSortsByZAndDefaultsToZero(t *testing.T) {
 		}
 	}
 }
+
+func TestCollectDrawablesReusesOrderUntilDrawablesChange(t *testing.T) {
+	world := ecs.NewWorld()
+
+	back := world.NewEntity()
+	world.AddComponent(back, &components.Transform{})
+	world.AddComponent(back, &components.Sprite{SpriteID: "back"})
+	world.AddComponent(back, &components.RenderOrder{Z: 0})
+
+	front := world.NewEntity()
+	world.AddComponent(front, &components.Transform{})
+	world.AddComponent(front, &components.Sprite{SpriteID: "front"})
+	world.AddComponent(front, &components.RenderOrder{Z: 5})
+
+	collectDrawables(world)
+	world.AdvanceTick()
+	collectDrawables(world)
+	world.AdvanceTick()
+
+	order, _ := ecs.GetResource[*drawOrder](world)
+	entities := order.entities
+
+	// Quiet ticks must not re-sort the cached order. A rebuild allocates a
+	// new slice.
+	for i := 0; i < 3; i++ {
+		collectDrawables(world)
+		if &order.entities[0] != &entities[0] {
+			t.Fatalf("order was rebuilt on quiet tick %d", i)
+		}
+		if order.builtAt != world.Tick() {
+			t.Fatalf("quiet tick %d: builtAt = %d, want the checked tick %d", i, order.builtAt, world.Tick())
+		}
+		world.AdvanceTick()
+	}
+
+	// Moving a sprite must not invalidate the cached order.
+	p, _ := ecs.Get[*components.Transform](world, front)
+	p.X = 50
+	world.MarkChanged(front, components.TypeTransform)
+	drawables := collectDrawables(world)
+	if &order.entities[0] != &entities[0] {
+		t.Fatalf("order was rebuilt after a transform-only change")
+	}
+	if drawables[1].transform.X != 50 {
+		t.Fatalf("drawables did not reflect the moved transform")
+	}
+
+	// Changing the z order does invalidate it.
+	world.AdvanceTick()
+	ro, _ := ecs.Get[*components.RenderOrder](world, front)
+	ro.Z = -5
+	world.MarkChanged(front, components.TypeRenderOrder)
+	drawables = collectDrawables(world)
+	if drawables[0].entity != front || drawables[1].entity != back {
+		t.Fatalf("order after z change = [%v %v], want [front back]", drawables[0].entity, drawables[1].entity)
+	}
+
+	// Destroying a drawable removes it.
+	world.AdvanceTick()
+	world.DestroyEntity(back)
+	if drawables = collectDrawables(world); len(drawables) != 1 || drawables[0].entity != front {
+		t.Fatalf("drawables after destroy = %v, want only front", drawables)
+	}
+}
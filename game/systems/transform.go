@@ -0,0 +1,59 @@
+package systems
+
+import (
+	"math"
+
+	"github.com/co0p/tankismus/game/components"
+	"github.com/co0p/tankismus/pkg/ecs"
+)
+
+// TransformPropagationSystem computes the world-space Transform of every
+// entity that has a LocalTransform and a parent in the ECS hierarchy.
+//
+// Starting at root entities, it walks the hierarchy depth-first so parents
+// are always resolved before their children. Children without a
+// LocalTransform keep their own Transform and still act as parents for their
+// subtree. A Scale of zero is treated as one, since scale is optional and
+// RenderSystem does not use it yet.
+func TransformPropagationSystem(world *ecs.World) {
+	ecs.NewQuery1[*components.Transform](world).Each(func(id ecs.EntityID, t *components.Transform) {
+		if _, hasParent := world.Parent(id); hasParent {
+			return
+		}
+		propagateTransforms(world, id, t)
+	})
+}
+
+func propagateTransforms(world *ecs.World, parent ecs.EntityID, pt *components.Transform) {
+	for _, child := range world.Children(parent) {
+		wt, okT := ecs.Get[*components.Transform](world, child)
+		if !okT {
+			continue
+		}
+		if local, okL := ecs.Get[*components.LocalTransform](world, child); okL {
+			prev := *wt
+			composeTransform(wt, pt, local)
+			if *wt != prev {
+				world.MarkChanged(child, components.TypeTransform)
+			}
+		}
+		propagateTransforms(world, child, wt)
+	}
+}
+
+// composeTransform writes parent ∘ local into out.
+func composeTransform(out, parent *components.Transform, local *components.LocalTransform) {
+	scale := unitScale(parent.Scale)
+	cos, sin := math.Cos(parent.Rotation), math.Sin(parent.Rotation)
+	out.X = parent.X + scale*(cos*local.X-sin*local.Y)
+	out.Y = parent.Y + scale*(sin*local.X+cos*local.Y)
+	out.Rotation = parent.Rotation + local.Rotation
+	out.Scale = scale * unitScale(local.Scale)
+}
+
+func unitScale(s float64) float64 {
+	if s == 0 {
+		return 1
+	}
+	return s
+}
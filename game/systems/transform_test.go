@@ -0,0 +1,63 @@
+package systems
+
+import (
+	"math"
+	"testing"
+
+	"github.com/co0p/tankismus/game/components"
+	"github.com/co0p/tankismus/pkg/ecs"
+)
+
+func almostEqual(a, b float64) bool {
+	return math.Abs(a-b) < 1e-9
+}
+
+func TestTransformPropagationSystem_ComposesNestedLocalTransforms(t *testing.T) {
+	world := ecs.NewWorld()
+
+	body := world.NewEntity()
+	world.AddComponent(body, &components.Transform{X: 100, Y: 50, Rotation: math.Pi / 2, Scale: 1})
+
+	turret := world.NewEntity()
+	world.AddComponent(turret, &components.Transform{})
+	world.AddComponent(turret, &components.LocalTransform{X: 10, Rotation: 0.5})
+	if err := world.SetParent(turret, body); err != nil {
+		t.Fatalf("SetParent(turret, body) failed: %v", err)
+	}
+
+	flash := world.NewEntity()
+	world.AddComponent(flash, &components.Transform{})
+	world.AddComponent(flash, &components.LocalTransform{X: 4})
+	if err := world.SetParent(flash, turret); err != nil {
+		t.Fatalf("SetParent(flash, turret) failed: %v", err)
+	}
+
+	TransformPropagationSystem(world)
+
+	tt, _ := ecs.Get[*components.Transform](world, turret)
+	// Body faces +Y, so a local offset along +X ends up along +Y in world space.
+	if !almostEqual(tt.X, 100) || !almostEqual(tt.Y, 60) || !almostEqual(tt.Rotation, math.Pi/2+0.5) {
+		t.Fatalf("turret world transform = %+v, want X=100 Y=60 Rotation=%v", *tt, math.Pi/2+0.5)
+	}
+
+	ft, _ := ecs.Get[*components.Transform](world, flash)
+	wantX := 100 + 4*math.Cos(math.Pi/2+0.5)
+	wantY := 60 + 4*math.Sin(math.Pi/2+0.5)
+	if !almostEqual(ft.X, wantX) || !almostEqual(ft.Y, wantY) {
+		t.Fatalf("flash world position = (%v, %v), want (%v, %v)", ft.X, ft.Y, wantX, wantY)
+	}
+}
+
+func TestTransformPropagationSystem_LeavesRootsUntouched(t *testing.T) {
+	world := ecs.NewWorld()
+	root := world.NewEntity()
+	world.AddComponent(root, &components.Transform{X: 1, Y: 2, Rotation: 3, Scale: 1})
+	world.AddComponent(root, &components.LocalTransform{X: 50})
+
+	TransformPropagationSystem(world)
+
+	rt, _ := ecs.Get[*components.Transform](world, root)
+	if rt.X != 1 || rt.Y != 2 || rt.Rotation != 3 {
+		t.Fatalf("root transform changed to %+v", *rt)
+	}
+}
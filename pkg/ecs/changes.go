@@ -0,0 +1,136 @@
+package ecs
+
+// Change detection lets systems skip work for components that did not change.
+//
+// The World keeps a tick counter that Scheduler.Run advances once per frame.
+// Every component records the tick at which it was added and last changed.
+// Adding or replacing a component stamps it automatically; in-place
+// mutation through a pointer cannot be observed, so systems call
+// MarkChanged after modifying a component. Removals are logged for the
+// current and previous tick only, matching the lifetime of events, and at
+// most the latest maxRemovalLog removals per component type are kept, so
+// worlds that never advance their tick do not grow without bound.
+
+// Tick returns the current change-detection tick. A system that wants to
+// know what changed since it last ran remembers this value.
+func (w *World) Tick() uint64 {
+	return w.tick
+}
+
+// AdvanceTick starts a new change-detection tick and forgets removals older
+// than the previous tick. Scheduler.Run calls it automatically.
+func (w *World) AdvanceTick() {
+	w.tick++
+	for _, store := range w.stores {
+		if store != nil {
+			store.pruneRemovals(w.tick - 1)
+		}
+	}
+}
+
+// MarkChanged records that the entity's component of type t was modified
+// during the current tick.
+func (w *World) MarkChanged(id EntityID, t ComponentType) {
+	if store := w.store(t); store != nil {
+		store.markChanged(id, w.tick)
+	}
+}
+
+// AddedSince returns the entities whose component of type t was added at or
+// after tick, ordered by slot index like Find.
+func (w *World) AddedSince(t ComponentType, tick uint64) []EntityID {
+	return w.stampedSince(t, tick, func(s *componentStore) []uint64 { return s.added })
+}
+
+// ChangedSince returns the entities whose component of type t was added or
+// changed at or after tick, ordered by slot index like Find.
+func (w *World) ChangedSince(t ComponentType, tick uint64) []EntityID {
+	return w.stampedSince(t, tick, func(s *componentStore) []uint64 { return s.changed })
+}
+
+// RemovedSince returns the entities that lost their component of type t, or
+// were destroyed while holding one, at or after tick, in the order the
+// removals happened. Only removals from the current and previous tick are
+// retained, and of those at least the latest maxRemovalLog/2.
+func (w *World) RemovedSince(t ComponentType, tick uint64) []EntityID {
+	result := make([]EntityID, 0)
+	store := w.store(t)
+	if store == nil {
+		return result
+	}
+	for _, r := range store.removed {
+		if r.tick >= tick {
+			result = append(result, r.id)
+		}
+	}
+	return result
+}
+
+func (w *World) stampedSince(t ComponentType, tick uint64, stamps func(*componentStore) []uint64) []EntityID {
+	result := make([]EntityID, 0)
+	store := w.store(t)
+	if store == nil {
+		return result
+	}
+	for i, stamp := range stamps(store) {
+		if stamp >= tick {
+			result = append(result, store.dense[i])
+		}
+	}
+	sortBySlot(result)
+	return result
+}
+
+// filterKind selects which tick a Filter compares against.
+type filterKind int
+
+const (
+	filterAdded filterKind = iota
+	filterChanged
+)
+
+// Filter narrows a query to entities whose components were added or changed
+// recently. Pass filters to the NewQuery constructors.
+type Filter struct {
+	kind  filterKind
+	t     ComponentType
+	since uint64
+}
+
+// AddedFilter matches entities whose component of type t was added at or
+// after tick.
+func AddedFilter(t ComponentType, tick uint64) Filter {
+	return Filter{kind: filterAdded, t: t, since: tick}
+}
+
+// ChangedFilter matches entities whose component of type t was added or
+// changed at or after tick.
+func ChangedFilter(t ComponentType, tick uint64) Filter {
+	return Filter{kind: filterChanged, t: t, since: tick}
+}
+
+// matches reports whether an entity passes the filter.
+func (f Filter) matches(w *World, id EntityID) bool {
+	store := w.store(f.t)
+	if store == nil {
+		return false
+	}
+	i := store.index(id)
+	if i < 0 {
+		return false
+	}
+	if f.kind == filterAdded {
+		return store.added[i] >= f.since
+	}
+	return store.changed[i] >= f.since
+}
+
+// matchesAll reports whether an entity passes every filter.
+func matchesAll(w *World, id EntityID, filters []Filter) bool {
+	for _, f := range filters {
+		if !f.matches(w, id) {
+			return false
+		}
+	}
+	return true
+}
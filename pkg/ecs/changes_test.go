@@ -0,0 +1,106 @@
+package ecs
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestChangeDetection_TracksAddedChangedAndRemoved(t *testing.T) {
+	w := NewWorld()
+	a := w.NewEntity()
+	b := w.NewEntity()
+	w.AddComponent(a, &testPosition{})
+	w.AddComponent(b, &testPosition{})
+
+	start := w.Tick()
+	if got := w.AddedSince(testTypePosition, start); len(got) != 2 {
+		t.Fatalf("AddedSince(start) = %v, want both entities", got)
+	}
+
+	w.AdvanceTick()
+	since := w.Tick()
+	if got := w.ChangedSince(testTypePosition, since); len(got) != 0 {
+		t.Fatalf("ChangedSince on a quiet tick = %v, want none", got)
+	}
+
+	p, _ := Get[*testPosition](w, b)
+	p.X = 5
+	w.MarkChanged(b, testTypePosition)
+	if got := w.ChangedSince(testTypePosition, since); !reflect.DeepEqual(got, []EntityID{b}) {
+		t.Fatalf("ChangedSince after MarkChanged = %v, want [%v]", got, b)
+	}
+	if got := w.AddedSince(testTypePosition, since); len(got) != 0 {
+		t.Fatalf("AddedSince after a change = %v, want none", got)
+	}
+
+	w.RemoveComponent(a, testTypePosition)
+	if got := w.RemovedSince(testTypePosition, since); !reflect.DeepEqual(got, []EntityID{a}) {
+		t.Fatalf("RemovedSince = %v, want [%v]", got, a)
+	}
+
+	// Removals survive one more tick and are then forgotten.
+	w.AdvanceTick()
+	if got := w.RemovedSince(testTypePosition, since); len(got) != 1 {
+		t.Fatalf("RemovedSince one tick later = %v, want [%v]", got, a)
+	}
+	w.AdvanceTick()
+	if got := w.RemovedSince(testTypePosition, since); len(got) != 0 {
+		t.Fatalf("RemovedSince two ticks later = %v, want none", got)
+	}
+}
+
+func TestQueryFilters_SkipUnchangedEntities(t *testing.T) {
+	w := NewWorld()
+	moving := w.NewEntity()
+	w.AddComponent(moving, &testPosition{})
+	w.AddComponent(moving, &testVelocity{})
+	parked := w.NewEntity()
+	w.AddComponent(parked, &testPosition{})
+	w.AddComponent(parked, &testVelocity{})
+
+	w.AdvanceTick()
+	since := w.Tick()
+	w.MarkChanged(moving, testTypePosition)
+	spawned := w.NewEntity()
+	w.AddComponent(spawned, &testPosition{})
+	w.AddComponent(spawned, &testVelocity{})
+
+	var changed []EntityID
+	NewQuery2[*testPosition, *testVelocity](w, ChangedFilter(testTypePosition, since)).
+		Each(func(id EntityID, _ *testPosition, _ *testVelocity) { changed = append(changed, id) })
+	if len(changed) != 2 || changed[0] == parked || changed[1] == parked {
+		t.Fatalf("changed query visited %v, want moving and spawned only", changed)
+	}
+
+	var added []EntityID
+	NewQuery1[*testPosition](w, AddedFilter(testTypePosition, since)).
+		Each(func(id EntityID, _ *testPosition) { added = append(added, id) })
+	if !reflect.DeepEqual(added, []EntityID{spawned}) {
+		t.Fatalf("added query visited %v, want [%v]", added, spawned)
+	}
+}
+
+func TestChangeDetection_RemovalLogIsBoundedWithoutScheduler(t *testing.T) {
+	w := NewWorld()
+	for i := 0; i < 3*maxRemovalLog; i++ {
+		id := w.NewEntity()
+		w.AddComponent(id, &testPosition{})
+		w.DestroyEntity(id)
+	}
+	if n := len(w.store(testTypePosition).removed); n > maxRemovalLog {
+		t.Fatalf("removal log holds %d records, want at most %d", n, maxRemovalLog)
+	}
+	if n := len(w.RemovedSince(testTypePosition, w.Tick())); n < maxRemovalLog/2 {
+		t.Fatalf("RemovedSince returned %d removals, want at least the latest %d", n, maxRemovalLog/2)
+	}
+
+	// Removals logged before the previous tick are dropped on the next
+	// removal even if AdvanceTick pruning never ran.
+	w.tick += 5
+	id := w.NewEntity()
+	w.AddComponent(id, &testPosition{})
+	w.DestroyEntity(id)
+	if got := w.RemovedSince(testTypePosition, 0); len(got) != 1 || got[0] != id {
+		t.Fatalf("RemovedSince(0) = %v, want only the latest removal %v", got, id)
+	}
+}
@@ -0,0 +1,98 @@
+package ecs
+
+// commandKind identifies a recorded structural change.
+type commandKind int
+
+const (
+	commandSpawn commandKind = iota
+	commandDestroy
+	commandAdd
+	commandRemove
+)
+
+// command is a single structural change recorded by a CommandBuffer.
+type command struct {
+	kind       commandKind
+	id         EntityID
+	t          ComponentType
+	components []Component
+}
+
+// CommandBuffer records structural changes to a World — creating and
+// destroying entities, adding and removing components — and applies them
+// later at a well-defined sync point.
+//
+// Systems use it while iterating Find or query results so that the set of
+// entities being iterated does not change underneath them. Commands are
+// applied in the order they were recorded. Operations that target an entity
+// which is no longer alive when the buffer is applied are skipped, because
+// the World ignores stale IDs.
+type CommandBuffer struct {
+	commands []command
+}
+
+// NewCommandBuffer constructs an empty command buffer.
+func NewCommandBuffer() *CommandBuffer {
+	return &CommandBuffer{}
+}
+
+// Spawn records the creation of a new entity with the given components.
+func (b *CommandBuffer) Spawn(components ...Component) {
+	b.commands = append(b.commands, command{kind: commandSpawn, components: components})
+}
+
+// Destroy records the destruction of an entity.
+func (b *CommandBuffer) Destroy(id EntityID) {
+	b.commands = append(b.commands, command{kind: commandDestroy, id: id})
+}
+
+// AddComponent records attaching a component to an entity.
+func (b *CommandBuffer) AddComponent(id EntityID, c Component) {
+	b.commands = append(b.commands, command{kind: commandAdd, id: id, components: []Component{c}})
+}
+
+// RemoveComponent records detaching a component type from an entity.
+func (b *CommandBuffer) RemoveComponent(id EntityID, t ComponentType) {
+	b.commands = append(b.commands, command{kind: commandRemove, id: id, t: t})
+}
+
+// Len returns the number of recorded, not yet applied commands.
+func (b *CommandBuffer) Len() int {
+	return len(b.commands)
+}
+
+// Apply executes all recorded commands against the world in recording order
+// and empties the buffer.
+func (b *CommandBuffer) Apply(w *World) {
+	commands := b.commands
+	b.commands = nil
+	for _, cmd := range commands {
+		switch cmd.kind {
+		case commandSpawn:
+			id := w.NewEntity()
+			for _, c := range cmd.components {
+				w.AddComponent(id, c)
+			}
+		case commandDestroy:
+			w.DestroyEntity(cmd.id)
+		case commandAdd:
+			w.AddComponent(cmd.id, cmd.components[0])
+		case commandRemove:
+			w.RemoveComponent(cmd.id, cmd.t)
+		}
+	}
+}
+
+// Commands returns the world's own command buffer. Systems record structural
+// changes here while iterating; they take effect when FlushCommands is called.
+func (w *World) Commands() *CommandBuffer {
+	return w.commands
+}
+
+// FlushCommands applies and clears the world's command buffer. It is the
+// sync point between systems and should be called when no iteration over the
+// world is in progress.
+func (w *World) FlushCommands() {
+	w.checkStructural("FlushCommands")
+	w.commands.Apply(w)
+}
@@ -0,0 +1,67 @@
+package ecs
+
+import "testing"
+
+func TestCommandBuffer_DefersChangesUntilFlush(t *testing.T) {
+	w := NewWorld()
+	doomed := w.NewEntity()
+	w.AddComponent(doomed, &testPosition{})
+	w.AddComponent(doomed, &testVelocity{})
+	shooter := w.NewEntity()
+	w.AddComponent(shooter, &testPosition{})
+	w.AddComponent(shooter, &testVelocity{DX: 1})
+
+	visited := 0
+	NewQuery2[*testPosition, *testVelocity](w).Each(func(id EntityID, _ *testPosition, v *testVelocity) {
+		visited++
+		if v.DX == 0 {
+			w.Commands().Destroy(id)
+			return
+		}
+		w.Commands().Spawn(&testPosition{X: 10}, &testVelocity{DX: 5})
+		w.Commands().AddComponent(id, testTag{})
+	})
+
+	if visited != 2 {
+		t.Fatalf("visited %d entities, want 2", visited)
+	}
+	if !w.IsAlive(doomed) || w.HasComponent(shooter, testTypeTag) {
+		t.Fatalf("commands must not be applied before FlushCommands")
+	}
+	if got := w.Commands().Len(); got != 3 {
+		t.Fatalf("Commands().Len() = %d, want 3", got)
+	}
+
+	w.FlushCommands()
+
+	if w.IsAlive(doomed) {
+		t.Fatalf("destroyed entity still alive after flush")
+	}
+	if !w.HasComponent(shooter, testTypeTag) {
+		t.Fatalf("deferred AddComponent was not applied")
+	}
+	if got := len(w.Find(MaskFor(testTypePosition, testTypeVelocity))); got != 2 {
+		t.Fatalf("found %d moving entities after flush, want shooter and spawned projectile", got)
+	}
+	if got := w.Commands().Len(); got != 0 {
+		t.Fatalf("Commands().Len() after flush = %d, want 0", got)
+	}
+}
+
+func TestCommandBuffer_SkipsCommandsForDestroyedEntities(t *testing.T) {
+	w := NewWorld()
+	id := w.NewEntity()
+
+	b := NewCommandBuffer()
+	b.Destroy(id)
+	b.AddComponent(id, &testPosition{})
+	b.RemoveComponent(id, testTypePosition)
+	b.Apply(w)
+
+	if w.IsAlive(id) {
+		t.Fatalf("entity resurrected by command recorded after its destruction")
+	}
+	if got := len(w.Find(MaskFor(testTypePosition))); got != 0 {
+		t.Fatalf("found %d positioned entities, want 0", got)
+	}
+}
@@ -0,0 +1,70 @@
+package ecs
+
+import (
+	"reflect"
+	"testing"
+)
+
+// scriptedWorld applies a fixed sequence of structural operations that
+// exercises slot recycling and swap-removal in the component stores.
+func scriptedWorld() *World {
+	w := NewWorld()
+	ids := make([]EntityID, 0, 64)
+	for i := 0; i < 64; i++ {
+		id := w.NewEntity()
+		ids = append(ids, id)
+		// Add components in varying order so dense order differs per store.
+		if i%2 == 0 {
+			w.AddComponent(id, &testVelocity{DX: float64(i)})
+			w.AddComponent(id, &testPosition{X: float64(i)})
+		} else {
+			w.AddComponent(id, &testPosition{X: float64(i)})
+			if i%3 == 0 {
+				w.AddComponent(id, &testVelocity{DX: float64(i)})
+			}
+		}
+	}
+	for i := 0; i < 64; i += 5 {
+		w.DestroyEntity(ids[i])
+	}
+	for i := 1; i < 64; i += 7 {
+		w.RemoveComponent(ids[i], testTypePosition)
+	}
+	for i := 0; i < 10; i++ {
+		id := w.NewEntity()
+		w.AddComponent(id, &testPosition{X: float64(100 + i)})
+		w.AddComponent(id, &testVelocity{})
+	}
+	return w
+}
+
+func TestWorld_FindOrderIsDeterministicAcrossRuns(t *testing.T) {
+	required := MaskFor(testTypePosition, testTypeVelocity)
+	want := scriptedWorld().Find(required)
+
+	for run := 0; run < 20; run++ {
+		if got := scriptedWorld().Find(required); !reflect.DeepEqual(got, want) {
+			t.Fatalf("run %d: Find = %v, want %v", run, got, want)
+		}
+	}
+
+	var fromQuery []EntityID
+	NewQuery2[*testPosition, *testVelocity](scriptedWorld()).Each(func(id EntityID, _ *testPosition, _ *testVelocity) {
+		fromQuery = append(fromQuery, id)
+	})
+	if !reflect.DeepEqual(fromQuery, want) {
+		t.Fatalf("query order = %v, want Find order %v", fromQuery, want)
+	}
+}
+
+func TestWorld_FindOrdersBySlotIndex(t *testing.T) {
+	w := scriptedWorld()
+	for _, mask := range []Mask{MaskFor(testTypePosition), MaskFor(testTypeVelocity), MaskFor(testTypePosition, testTypeVelocity)} {
+		got := w.Find(mask)
+		for i := 1; i < len(got); i++ {
+			if got[i-1].Index() >= got[i].Index() {
+				t.Fatalf("Find(%v) not ordered by slot: %v before %v", mask.Types(), got[i-1], got[i])
+			}
+		}
+	}
+}
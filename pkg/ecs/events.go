@@ -0,0 +1,127 @@
+package ecs
+
+import "reflect"
+
+// Events are typed, frame-scoped messages that let systems react to each
+// other without sharing components.
+//
+// Any Go type can be an event. An event emitted during frame N can be read
+// for the rest of frame N and during frame N+1; it is dropped when frame N+2
+// begins. This double buffering lets a system that runs early in the frame
+// see events emitted by systems that run after it. Frames are advanced by
+// AdvanceEvents, which Scheduler.Run calls at the start of every run.
+
+// eventQueue holds the buffered events of a single type.
+type eventQueue[E any] struct {
+	previous []E
+	current  []E
+	// base is the sequence number of previous[0].
+	base uint64
+}
+
+// advance starts a new frame, dropping events from two frames ago.
+func (q *eventQueue[E]) advance() {
+	q.base += uint64(len(q.previous))
+	q.previous, q.current = q.current, q.previous[:0]
+}
+
+// eventAdvancer is the type-erased view of an eventQueue.
+type eventAdvancer interface {
+	advance()
+}
+
+// eventBus stores one queue per event type.
+type eventBus struct {
+	queues map[reflect.Type]eventAdvancer
+}
+
+func newEventBus() *eventBus {
+	return &eventBus{queues: make(map[reflect.Type]eventAdvancer)}
+}
+
+// queueFor returns the queue for event type E, creating it if requested.
+func queueFor[E any](b *eventBus, create bool) *eventQueue[E] {
+	key := reflect.TypeFor[E]()
+	if q, ok := b.queues[key]; ok {
+		return q.(*eventQueue[E])
+	}
+	if !create {
+		return nil
+	}
+	q := &eventQueue[E]{}
+	b.queues[key] = q
+	return q
+}
+
+// stagedEvents records events emitted by a system running in a parallel
+// batch until the scheduler merges them into the world's bus.
+type stagedEvents struct {
+	emits []func(*eventBus)
+}
+
+// emitTo replays the staged events into b in emission order.
+func (s *stagedEvents) emitTo(b *eventBus) {
+	for _, emit := range s.emits {
+		emit(b)
+	}
+}
+
+// push appends an event to the current frame of its queue.
+func push[E any](b *eventBus, e E) {
+	q := queueFor[E](b, true)
+	q.current = append(q.current, e)
+}
+
+// Emit publishes an event for the current frame.
+func Emit[E any](w *World, e E) {
+	if w.staged != nil {
+		w.staged.emits = append(w.staged.emits, func(b *eventBus) { push(b, e) })
+		return
+	}
+	push(w.events, e)
+}
+
+// AdvanceEvents starts a new event frame. Events emitted two frames ago are
+// dropped. Scheduler.Run calls it automatically.
+func (w *World) AdvanceEvents() {
+	for _, q := range w.events.queues {
+		q.advance()
+	}
+}
+
+// EventReader reads events of type E. Each reader remembers what it has
+// already seen, so every event is returned at most once per reader. The
+// zero value is ready to use; a system typically owns one reader per event
+// type it consumes.
+type EventReader[E any] struct {
+	// next is the sequence number of the next unread event.
+	next uint64
+}
+
+// Read returns all events of type E emitted since the previous call that are
+// still buffered. Events the reader did not read before they were dropped
+// are skipped.
+func (r *EventReader[E]) Read(w *World) []E {
+	q := queueFor[E](w.events, false)
+	if q == nil {
+		return nil
+	}
+	if r.next < q.base {
+		r.next = q.base
+	}
+	end := q.base + uint64(len(q.previous)+len(q.current))
+	if r.next >= end {
+		return nil
+	}
+	result := make([]E, 0, end-r.next)
+	for seq := r.next; seq < end; seq++ {
+		i := int(seq - q.base)
+		if i < len(q.previous) {
+			result = append(result, q.previous[i])
+		} else {
+			result = append(result, q.current[i-len(q.previous)])
+		}
+	}
+	r.next = end
+	return result
+}
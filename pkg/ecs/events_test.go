@@ -0,0 +1,90 @@
+package ecs
+
+import (
+	"reflect"
+	"testing"
+)
+
+type testHit struct{ Target EntityID }
+
+type testWaveStarted struct{ Wave int }
+
+func TestEvents_AreReadOncePerReaderAndExpireAfterNextFrame(t *testing.T) {
+	w := NewWorld()
+	var audio, score EventReader[testHit]
+
+	Emit(w, testHit{Target: 1})
+	Emit(w, testHit{Target: 2})
+
+	if got := audio.Read(w); !reflect.DeepEqual(got, []testHit{{1}, {2}}) {
+		t.Fatalf("audio read %v, want hits 1 and 2", got)
+	}
+	if got := audio.Read(w); len(got) != 0 {
+		t.Fatalf("audio re-read %v, want no events", got)
+	}
+
+	// Next frame: events from the previous frame are still readable by a
+	// reader that has not seen them yet.
+	w.AdvanceEvents()
+	Emit(w, testHit{Target: 3})
+	if got := score.Read(w); !reflect.DeepEqual(got, []testHit{{1}, {2}, {3}}) {
+		t.Fatalf("score read %v, want hits 1, 2 and 3", got)
+	}
+	if got := audio.Read(w); !reflect.DeepEqual(got, []testHit{{3}}) {
+		t.Fatalf("audio read %v, want hit 3", got)
+	}
+
+	// Two frames later everything emitted before is gone.
+	w.AdvanceEvents()
+	w.AdvanceEvents()
+	var late EventReader[testHit]
+	if got := late.Read(w); len(got) != 0 {
+		t.Fatalf("late reader got expired events %v", got)
+	}
+}
+
+func TestEvents_TypesAreIndependent(t *testing.T) {
+	w := NewWorld()
+	Emit(w, testWaveStarted{Wave: 4})
+
+	var hits EventReader[testHit]
+	if got := hits.Read(w); len(got) != 0 {
+		t.Fatalf("hit reader got %v, want nothing", got)
+	}
+	var waves EventReader[testWaveStarted]
+	if got := waves.Read(w); len(got) != 1 || got[0].Wave != 4 {
+		t.Fatalf("wave reader got %v, want wave 4", got)
+	}
+}
+
+func TestScheduler_ParallelSystemsEmitEventsInSequentialOrder(t *testing.T) {
+	for _, parallel := range []bool{false, true} {
+		w := NewWorld()
+		s := NewScheduler()
+		s.SetParallel(parallel)
+		for i, ct := range []ComponentType{testTypePosition, testTypeVelocity, testTypeTag} {
+			_ = s.Add(System{
+				Name:   string(rune('a' + i)),
+				Stage:  StageSimulation,
+				Writes: []ComponentType{ct},
+				Run: func(w *World, _ float64) {
+					for j := 0; j < 3; j++ {
+						Emit(w, testHit{Target: EntityID(i*10 + j)})
+					}
+				},
+			})
+		}
+		var got []testHit
+		var reader EventReader[testHit]
+		_ = s.Add(System{Name: "consume", Stage: StagePostPhysics, Run: func(w *World, _ float64) {
+			got = reader.Read(w)
+		}})
+
+		s.Run(w, 0)
+
+		want := []testHit{{0}, {1}, {2}, {10}, {11}, {12}, {20}, {21}, {22}}
+		if !reflect.DeepEqual(got, want) {
+			t.Fatalf("parallel=%v: consumer read %v, want %v", parallel, got, want)
+		}
+	}
+}
@@ -0,0 +1,82 @@
+package ecs
+
+import (
+	"errors"
+	"fmt"
+)
+
+var (
+	ErrEntityNotAlive = errors.New("ecs: entity is not alive")
+	ErrHierarchyCycle = errors.New("ecs: parent relationship would create a cycle")
+)
+
+// hierarchy tracks parent/child relationships between entities.
+//
+// Relationships are world metadata rather than components, so pkg/ecs does
+// not need to reserve ComponentType values of its own. Children keep the
+// order in which they were attached.
+type hierarchy struct {
+	parent   map[EntityID]EntityID
+	children map[EntityID][]EntityID
+}
+
+func newHierarchy() *hierarchy {
+	return &hierarchy{
+		parent:   make(map[EntityID]EntityID),
+		children: make(map[EntityID][]EntityID),
+	}
+}
+
+// SetParent attaches child to parent, detaching it from any previous parent.
+// Destroying the parent later destroys the child as well.
+func (w *World) SetParent(child, parent EntityID) error {
+	w.checkStructural("SetParent")
+	if !w.IsAlive(child) {
+		return fmt.Errorf("%w: child %d", ErrEntityNotAlive, child)
+	}
+	if !w.IsAlive(parent) {
+		return fmt.Errorf("%w: parent %d", ErrEntityNotAlive, parent)
+	}
+	for ancestor, ok := parent, true; ok; ancestor, ok = w.hierarchy.parent[ancestor] {
+		if ancestor == child {
+			return fmt.Errorf("%w: %d is an ancestor of %d", ErrHierarchyCycle, child, parent)
+		}
+	}
+	w.RemoveParent(child)
+	w.hierarchy.parent[child] = parent
+	w.hierarchy.children[parent] = append(w.hierarchy.children[parent], child)
+	return nil
+}
+
+// RemoveParent detaches child from its parent, making it a root entity.
+func (w *World) RemoveParent(child EntityID) {
+	w.checkStructural("RemoveParent")
+	parent, ok := w.hierarchy.parent[child]
+	if !ok {
+		return
+	}
+	delete(w.hierarchy.parent, child)
+	siblings := w.hierarchy.children[parent]
+	for i, sibling := range siblings {
+		if sibling == child {
+			siblings = append(siblings[:i], siblings[i+1:]...)
+			break
+		}
+	}
+	if len(siblings) == 0 {
+		delete(w.hierarchy.children, parent)
+	} else {
+		w.hierarchy.children[parent] = siblings
+	}
+}
+
+// Parent returns the parent of an entity, if it has one.
+func (w *World) Parent(child EntityID) (EntityID, bool) {
+	parent, ok := w.hierarchy.parent[child]
+	return parent, ok
+}
+
+// Children returns a copy of an entity's children in attach order.
+func (w *World) Children(parent EntityID) []EntityID {
+	return append([]EntityID(nil), w.hierarchy.children[parent]...)
+}
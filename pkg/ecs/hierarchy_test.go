@@ -0,0 +1,104 @@
+package ecs
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func TestHierarchy_SetParentAndReparent(t *testing.T) {
+	w := NewWorld()
+	tank := w.NewEntity()
+	turret := w.NewEntity()
+	barrel := w.NewEntity()
+	other := w.NewEntity()
+
+	if err := w.SetParent(turret, tank); err != nil {
+		t.Fatalf("SetParent(turret, tank) failed: %v", err)
+	}
+	if err := w.SetParent(barrel, turret); err != nil {
+		t.Fatalf("SetParent(barrel, turret) failed: %v", err)
+	}
+
+	if p, ok := w.Parent(barrel); !ok || p != turret {
+		t.Fatalf("Parent(barrel) = %v, %v; want turret", p, ok)
+	}
+	if got := w.Children(tank); !reflect.DeepEqual(got, []EntityID{turret}) {
+		t.Fatalf("Children(tank) = %v, want [turret]", got)
+	}
+
+	if err := w.SetParent(barrel, other); err != nil {
+		t.Fatalf("reparenting failed: %v", err)
+	}
+	if got := w.Children(turret); len(got) != 0 {
+		t.Fatalf("old parent still lists child after reparent: %v", got)
+	}
+	if got := w.Children(other); !reflect.DeepEqual(got, []EntityID{barrel}) {
+		t.Fatalf("Children(other) = %v, want [barrel]", got)
+	}
+
+	w.RemoveParent(barrel)
+	if _, ok := w.Parent(barrel); ok {
+		t.Fatalf("barrel still has a parent after RemoveParent")
+	}
+}
+
+func TestHierarchy_RejectsCyclesAndDeadEntities(t *testing.T) {
+	w := NewWorld()
+	a := w.NewEntity()
+	b := w.NewEntity()
+	_ = w.SetParent(b, a)
+
+	if err := w.SetParent(a, b); !errors.Is(err, ErrHierarchyCycle) {
+		t.Fatalf("SetParent creating a cycle = %v, want ErrHierarchyCycle", err)
+	}
+	if err := w.SetParent(a, a); !errors.Is(err, ErrHierarchyCycle) {
+		t.Fatalf("SetParent to self = %v, want ErrHierarchyCycle", err)
+	}
+
+	dead := w.NewEntity()
+	w.DestroyEntity(dead)
+	if err := w.SetParent(dead, a); !errors.Is(err, ErrEntityNotAlive) {
+		t.Fatalf("SetParent with dead child = %v, want ErrEntityNotAlive", err)
+	}
+}
+
+func TestHierarchy_DestroyIsRecursive(t *testing.T) {
+	w := NewWorld()
+	tank := w.NewEntity()
+	turret := w.NewEntity()
+	flash := w.NewEntity()
+	healthBar := w.NewEntity()
+	_ = w.SetParent(turret, tank)
+	_ = w.SetParent(flash, turret)
+	_ = w.SetParent(healthBar, tank)
+
+	var destroyed []EntityID
+	w.OnRemove(testTypeTag, func(_ *World, id EntityID, _ Component) {
+		destroyed = append(destroyed, id)
+	})
+	for _, id := range []EntityID{tank, turret, flash, healthBar} {
+		w.AddComponent(id, testTag{})
+	}
+
+	w.DestroyEntity(tank)
+
+	for _, id := range []EntityID{tank, turret, flash, healthBar} {
+		if w.IsAlive(id) {
+			t.Fatalf("entity %v survived destruction of its ancestor", id)
+		}
+	}
+	want := []EntityID{flash, turret, healthBar, tank}
+	if !reflect.DeepEqual(destroyed, want) {
+		t.Fatalf("destroy order = %v, want children before parents %v", destroyed, want)
+	}
+
+	// Destroying a child detaches it from its parent.
+	parent := w.NewEntity()
+	child := w.NewEntity()
+	_ = w.SetParent(child, parent)
+	w.DestroyEntity(child)
+	if got := w.Children(parent); len(got) != 0 {
+		t.Fatalf("destroyed child still listed: %v", got)
+	}
+}
@@ -0,0 +1,118 @@
+package ecs
+
+import "math/bits"
+
+// Mask is a variable-width set of component types.
+//
+// Each ComponentType maps to one bit; the backing words grow on demand so
+// there is no upper bound on the number of component types a game registers.
+// The zero Mask is empty and ready to use.
+type Mask struct {
+	words []uint64
+}
+
+// MaskFor computes a mask for the given component types.
+func MaskFor(types ...ComponentType) Mask {
+	var m Mask
+	for _, t := range types {
+		m.Set(t)
+	}
+	return m
+}
+
+// Set adds a component type to the mask.
+func (m *Mask) Set(t ComponentType) {
+	if t < 0 {
+		return
+	}
+	word := int(t) / 64
+	if word >= len(m.words) {
+		m.words = append(m.words, make([]uint64, word+1-len(m.words))...)
+	}
+	m.words[word] |= 1 << (uint(t) % 64)
+}
+
+// Clear removes a component type from the mask.
+func (m *Mask) Clear(t ComponentType) {
+	if t < 0 {
+		return
+	}
+	word := int(t) / 64
+	if word >= len(m.words) {
+		return
+	}
+	m.words[word] &^= 1 << (uint(t) % 64)
+}
+
+// Has reports whether the mask contains a component type.
+func (m Mask) Has(t ComponentType) bool {
+	if t < 0 {
+		return false
+	}
+	word := int(t) / 64
+	if word >= len(m.words) {
+		return false
+	}
+	return m.words[word]&(1<<(uint(t)%64)) != 0
+}
+
+// Contains reports whether m contains every component type in other.
+func (m Mask) Contains(other Mask) bool {
+	for i, want := range other.words {
+		var have uint64
+		if i < len(m.words) {
+			have = m.words[i]
+		}
+		if have&want != want {
+			return false
+		}
+	}
+	return true
+}
+
+// Intersects reports whether m and other share at least one component type.
+func (m Mask) Intersects(other Mask) bool {
+	n := min(len(m.words), len(other.words))
+	for i := 0; i < n; i++ {
+		if m.words[i]&other.words[i] != 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// IsEmpty reports whether the mask contains no component types.
+func (m Mask) IsEmpty() bool {
+	for _, word := range m.words {
+		if word != 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// Equal reports whether both masks contain exactly the same component types.
+func (m Mask) Equal(other Mask) bool {
+	return m.Contains(other) && other.Contains(m)
+}
+
+// Types returns the component types in the mask in ascending order.
+func (m Mask) Types() []ComponentType {
+	types := make([]ComponentType, 0)
+	for i, word := range m.words {
+		for word != 0 {
+			bit := bits.TrailingZeros64(word)
+			types = append(types, ComponentType(i*64+bit))
+			word &^= 1 << uint(bit)
+		}
+	}
+	return types
+}
+
+// Clone returns a copy of the mask that does not share storage with m.
+func (m Mask) Clone() Mask {
+	if m.words == nil {
+		return Mask{}
+	}
+	return Mask{words: append([]uint64(nil), m.words...)}
+}
@@ -0,0 +1,70 @@
+package ecs
+
+import "testing"
+
+func TestMask_SupportsMoreThan64ComponentTypes(t *testing.T) {
+	m := MaskFor(3, 64, 200)
+
+	for _, ct := range []ComponentType{3, 64, 200} {
+		if !m.Has(ct) {
+			t.Fatalf("mask missing type %d", ct)
+		}
+	}
+	if m.Has(0) || m.Has(65) || m.Has(1000) {
+		t.Fatalf("mask reports types that were never set")
+	}
+
+	got := m.Types()
+	want := []ComponentType{3, 64, 200}
+	if len(got) != len(want) {
+		t.Fatalf("Types() = %v, want %v", got, want)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Fatalf("Types() = %v, want %v", got, want)
+		}
+	}
+
+	m.Clear(64)
+	if m.Has(64) {
+		t.Fatalf("Clear(64) did not remove the type")
+	}
+}
+
+func TestMask_ContainsAcrossDifferentWidths(t *testing.T) {
+	wide := MaskFor(1, 130)
+	narrow := MaskFor(1)
+
+	if !wide.Contains(narrow) {
+		t.Fatalf("wide mask should contain narrow mask")
+	}
+	if narrow.Contains(wide) {
+		t.Fatalf("narrow mask must not contain wide mask")
+	}
+	if !narrow.Equal(MaskFor(1)) || narrow.Equal(wide) {
+		t.Fatalf("Equal mismatch between %v and %v", narrow.Types(), wide.Types())
+	}
+}
+
+func TestWorld_FindWithHighComponentTypes(t *testing.T) {
+	w := NewWorld()
+	a := w.NewEntity()
+	w.AddComponent(a, &testPosition{})
+	w.AddComponent(a, highComponent{})
+	b := w.NewEntity()
+	w.AddComponent(b, &testPosition{})
+
+	got := w.Find(MaskFor(testTypePosition, testTypeHigh))
+	if len(got) != 1 || got[0] != a {
+		t.Fatalf("Find = %v, want [%v]", got, a)
+	}
+	if !w.HasComponent(a, testTypeHigh) {
+		t.Fatalf("HasComponent(high) = false")
+	}
+}
+
+const testTypeHigh ComponentType = 150
+
+type highComponent struct{}
+
+func (highComponent) Type() ComponentType { return testTypeHigh }
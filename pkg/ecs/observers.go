@@ -0,0 +1,49 @@
+package ecs
+
+// Hook is called when a component is attached to or detached from an entity.
+// c is the component value being added or removed.
+//
+// Hooks run synchronously inside AddComponent, RemoveComponent and
+// DestroyEntity. They may read the world freely; structural changes are best
+// recorded through Commands so they apply at the next sync point.
+type Hook func(w *World, id EntityID, c Component)
+
+// observers holds the registered hooks per component type.
+type observers struct {
+	onAdd    map[ComponentType][]Hook
+	onRemove map[ComponentType][]Hook
+}
+
+func newObservers() *observers {
+	return &observers{
+		onAdd:    make(map[ComponentType][]Hook),
+		onRemove: make(map[ComponentType][]Hook),
+	}
+}
+
+// OnAdd registers a hook that runs whenever a component of type t is added
+// to an entity, including when it replaces an existing component.
+func (w *World) OnAdd(t ComponentType, h Hook) {
+	w.checkStructural("OnAdd")
+	w.observers.onAdd[t] = append(w.observers.onAdd[t], h)
+}
+
+// OnRemove registers a hook that runs whenever a component of type t is
+// removed from an entity, replaced by AddComponent, or destroyed together
+// with its entity.
+func (w *World) OnRemove(t ComponentType, h Hook) {
+	w.checkStructural("OnRemove")
+	w.observers.onRemove[t] = append(w.observers.onRemove[t], h)
+}
+
+func (o *observers) notifyAdd(w *World, id EntityID, c Component) {
+	for _, h := range o.onAdd[c.Type()] {
+		h(w, id, c)
+	}
+}
+
+func (o *observers) notifyRemove(w *World, id EntityID, c Component) {
+	for _, h := range o.onRemove[c.Type()] {
+		h(w, id, c)
+	}
+}
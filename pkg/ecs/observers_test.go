@@ -0,0 +1,64 @@
+package ecs
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestObservers_FireOnAddRemoveAndDestroy(t *testing.T) {
+	w := NewWorld()
+	var log []string
+	w.OnAdd(testTypePosition, func(_ *World, _ EntityID, c Component) {
+		log = append(log, "add", fmtX(c))
+	})
+	w.OnRemove(testTypePosition, func(w *World, id EntityID, c Component) {
+		// The entity is still intact while remove hooks run.
+		if !w.HasComponent(id, testTypePosition) {
+			t.Errorf("remove hook ran after the component was detached")
+		}
+		log = append(log, "remove", fmtX(c))
+	})
+
+	a := w.NewEntity()
+	w.AddComponent(a, &testPosition{X: 1})
+	w.AddComponent(a, &testPosition{X: 2}) // replace
+	w.AddComponent(a, &testVelocity{})     // other type: no hooks
+	w.RemoveComponent(a, testTypePosition)
+	w.RemoveComponent(a, testTypePosition) // already gone: no hooks
+
+	b := w.NewEntity()
+	w.AddComponent(b, &testPosition{X: 3})
+	w.DestroyEntity(b)
+
+	want := []string{
+		"add", "1",
+		"remove", "1", "add", "2",
+		"remove", "2",
+		"add", "3",
+		"remove", "3",
+	}
+	if !reflect.DeepEqual(log, want) {
+		t.Fatalf("hook log = %v, want %v", log, want)
+	}
+}
+
+func TestObservers_HooksCanDeferStructuralChanges(t *testing.T) {
+	w := NewWorld()
+	w.OnRemove(testTypeVelocity, func(w *World, _ EntityID, _ Component) {
+		// e.g. spawn an explosion when a tank dies.
+		w.Commands().Spawn(testTag{})
+	})
+
+	tank := w.NewEntity()
+	w.AddComponent(tank, &testVelocity{})
+	w.DestroyEntity(tank)
+	w.FlushCommands()
+
+	if got := len(w.Find(MaskFor(testTypeTag))); got != 1 {
+		t.Fatalf("found %d explosions, want 1", got)
+	}
+}
+
+func fmtX(c Component) string {
+	return string(rune('0' + int(c.(*testPosition).X)))
+}
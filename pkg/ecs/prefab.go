@@ -0,0 +1,211 @@
+package ecs
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"slices"
+	"strings"
+)
+
+var (
+	ErrUnknownPrefab   = errors.New("ecs: unknown prefab")
+	ErrDuplicatePrefab = errors.New("ecs: prefab already defined")
+	ErrPrefabCycle     = errors.New("ecs: prefab inheritance cycle")
+)
+
+// Prefab is an entity template. Components are keyed by their registered
+// name and hold the component's JSON encoding.
+//
+// A prefab may extend another prefab. Its components are then merged over
+// the parent's: JSON objects are merged field by field, any other value
+// replaces the inherited one, and a null value drops an inherited component.
+type Prefab struct {
+	Extends    string                     `json:"extends,omitempty"`
+	Components map[string]json.RawMessage `json:"components"`
+}
+
+// PrefabLibrary holds named prefabs and instantiates them into worlds.
+type PrefabLibrary struct {
+	reg     *Registry
+	prefabs map[string]Prefab
+}
+
+// NewPrefabLibrary constructs an empty library that resolves component names
+// through reg.
+func NewPrefabLibrary(reg *Registry) *PrefabLibrary {
+	return &PrefabLibrary{reg: reg, prefabs: make(map[string]Prefab)}
+}
+
+// Add defines a prefab under name. Parents do not need to be defined yet;
+// inheritance is resolved when the prefab is instantiated.
+func (l *PrefabLibrary) Add(name string, p Prefab) error {
+	if _, ok := l.prefabs[name]; ok {
+		return fmt.Errorf("%w: %q", ErrDuplicatePrefab, name)
+	}
+	l.prefabs[name] = p
+	return nil
+}
+
+// Load reads a JSON object mapping prefab names to prefabs and adds each of
+// them, then checks that every prefab in the library can be instantiated.
+func (l *PrefabLibrary) Load(r io.Reader) error {
+	var defs map[string]Prefab
+	dec := json.NewDecoder(r)
+	dec.DisallowUnknownFields()
+	if err := dec.Decode(&defs); err != nil {
+		return fmt.Errorf("ecs: decoding prefabs: %w", err)
+	}
+	names := make([]string, 0, len(defs))
+	for name := range defs {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+	for _, name := range names {
+		if err := l.Add(name, defs[name]); err != nil {
+			return err
+		}
+	}
+	for _, name := range l.Names() {
+		if _, err := l.Instantiate(name); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Names returns the names of all defined prefabs in sorted order.
+func (l *PrefabLibrary) Names() []string {
+	names := make([]string, 0, len(l.prefabs))
+	for name := range l.prefabs {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+	return names
+}
+
+// Has reports whether a prefab with the given name is defined.
+func (l *PrefabLibrary) Has(name string) bool {
+	_, ok := l.prefabs[name]
+	return ok
+}
+
+// Instantiate returns freshly decoded components for a prefab, ordered by
+// ComponentType. The result can be passed to CommandBuffer.Spawn when a
+// system needs to create the entity while iterating.
+func (l *PrefabLibrary) Instantiate(name string) ([]Component, error) {
+	resolved, err := l.resolve(name, nil)
+	if err != nil {
+		return nil, err
+	}
+	components := make([]Component, 0, len(resolved))
+	for compName, data := range resolved {
+		c, ok := l.reg.New(compName)
+		if !ok {
+			return nil, fmt.Errorf("%w: %q in prefab %q", ErrUnregisteredComponent, compName, name)
+		}
+		// Reject unknown fields so a misspelled field in a prefab file fails
+		// Load instead of silently keeping its zero value.
+		dec := json.NewDecoder(bytes.NewReader(data))
+		dec.DisallowUnknownFields()
+		if err := dec.Decode(c); err != nil {
+			return nil, fmt.Errorf("ecs: decoding %q of prefab %q: %w", compName, name, err)
+		}
+		components = append(components, c)
+	}
+	slices.SortFunc(components, func(a, b Component) int {
+		return int(a.Type()) - int(b.Type())
+	})
+	return components, nil
+}
+
+// Spawn creates a new entity in w from the named prefab. Nothing is created
+// when the prefab cannot be instantiated.
+func (l *PrefabLibrary) Spawn(w *World, name string) (EntityID, error) {
+	components, err := l.Instantiate(name)
+	if err != nil {
+		return 0, err
+	}
+	id := w.NewEntity()
+	for _, c := range components {
+		w.AddComponent(id, c)
+	}
+	return id, nil
+}
+
+// resolve returns the merged component data of a prefab and its ancestors.
+// chain holds the prefabs currently being resolved and detects cycles.
+func (l *PrefabLibrary) resolve(name string, chain []string) (map[string]json.RawMessage, error) {
+	if slices.Contains(chain, name) {
+		return nil, fmt.Errorf("%w: %s", ErrPrefabCycle, strings.Join(append(chain, name), " -> "))
+	}
+	p, ok := l.prefabs[name]
+	if !ok {
+		if len(chain) > 0 {
+			return nil, fmt.Errorf("%w: %q (extended by %q)", ErrUnknownPrefab, name, chain[len(chain)-1])
+		}
+		return nil, fmt.Errorf("%w: %q", ErrUnknownPrefab, name)
+	}
+
+	resolved := make(map[string]json.RawMessage)
+	if p.Extends != "" {
+		parent, err := l.resolve(p.Extends, append(chain, name))
+		if err != nil {
+			return nil, err
+		}
+		resolved = parent
+	}
+	for compName, data := range p.Components {
+		if isJSONNull(data) {
+			delete(resolved, compName)
+			continue
+		}
+		merged, err := mergeJSON(resolved[compName], data)
+		if err != nil {
+			return nil, fmt.Errorf("ecs: merging %q of prefab %q: %w", compName, name, err)
+		}
+		resolved[compName] = merged
+	}
+	return resolved, nil
+}
+
+// mergeJSON merges override over base. When both are JSON objects their
+// fields are merged recursively; otherwise override replaces base. Field
+// names are matched exactly, so an override field that differs from a base
+// field only in case is rejected: encoding/json matches fields ignoring case
+// and which of the two values it decoded would be undefined.
+func mergeJSON(base, override json.RawMessage) (json.RawMessage, error) {
+	var overrideObj map[string]json.RawMessage
+	if err := json.Unmarshal(override, &overrideObj); err != nil || overrideObj == nil {
+		if !json.Valid(override) {
+			return nil, errors.New("invalid JSON")
+		}
+		return override, nil
+	}
+	var baseObj map[string]json.RawMessage
+	if base == nil || json.Unmarshal(base, &baseObj) != nil || baseObj == nil {
+		return override, nil
+	}
+	for field, value := range overrideObj {
+		if _, ok := baseObj[field]; !ok {
+			for existing := range baseObj {
+				if strings.EqualFold(existing, field) {
+					return nil, fmt.Errorf("field %q differs from inherited field %q only in case", field, existing)
+				}
+			}
+		}
+		merged, err := mergeJSON(baseObj[field], value)
+		if err != nil {
+			return nil, err
+		}
+		baseObj[field] = merged
+	}
+	return json.Marshal(baseObj)
+}
+
+// isJSONNull reports whether data is the JSON literal null.
+func isJSONNull(data json.RawMessage) bool {
+	return bytes.Equal(bytes.TrimSpace(data), []byte("null"))
+}
@@ -0,0 +1,100 @@
+package ecs
+
+import (
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+)
+
+const testPrefabs = `{
+	"mover": {
+		"components": {
+			"position": {"X": 1, "Y": 2},
+			"velocity": {"DX": 3, "DY": 4},
+			"tag": {}
+		}
+	},
+	"fast_mover": {
+		"extends": "mover",
+		"components": {
+			"velocity": {"DX": 9},
+			"tag": null
+		}
+	}
+}`
+
+func TestPrefabLibrary_SpawnAppliesInheritanceAndOverrides(t *testing.T) {
+	lib := NewPrefabLibrary(newTestRegistry())
+	if err := lib.Load(strings.NewReader(testPrefabs)); err != nil {
+		t.Fatalf("Load: %v", err)
+	}
+
+	w := NewWorld()
+	id, err := lib.Spawn(w, "fast_mover")
+	if err != nil {
+		t.Fatalf("Spawn: %v", err)
+	}
+
+	p, _ := Get[*testPosition](w, id)
+	if p == nil || p.X != 1 || p.Y != 2 {
+		t.Fatalf("inherited position = %+v, want {1 2}", p)
+	}
+	v, _ := Get[*testVelocity](w, id)
+	if v == nil || v.DX != 9 || v.DY != 4 {
+		t.Fatalf("merged velocity = %+v, want {9 4}", v)
+	}
+	if w.HasComponent(id, testTypeTag) {
+		t.Fatalf("null override must drop the inherited tag")
+	}
+
+	// Every spawn gets its own component values.
+	other, _ := lib.Spawn(w, "fast_mover")
+	p.X = 50
+	if q, _ := Get[*testPosition](w, other); q.X != 1 {
+		t.Fatalf("spawned entities share component values")
+	}
+}
+
+func TestPrefabLibrary_Errors(t *testing.T) {
+	lib := NewPrefabLibrary(newTestRegistry())
+	_ = lib.Add("a", Prefab{Extends: "b"})
+	_ = lib.Add("b", Prefab{Extends: "a"})
+	_ = lib.Add("orphan", Prefab{Extends: "missing"})
+	_ = lib.Add("bad", Prefab{Components: map[string]json.RawMessage{"unknown": json.RawMessage(`{}`)}})
+
+	w := NewWorld()
+	cases := map[string]error{
+		"a":      ErrPrefabCycle,
+		"orphan": ErrUnknownPrefab,
+		"nope":   ErrUnknownPrefab,
+		"bad":    ErrUnregisteredComponent,
+	}
+	for name, want := range cases {
+		if _, err := lib.Spawn(w, name); !errors.Is(err, want) {
+			t.Errorf("Spawn(%q) error = %v, want %v", name, err, want)
+		}
+	}
+	if id := w.NewEntity(); id.Index() != 1 {
+		t.Fatalf("failed spawns allocated entities; next slot = %d, want 1", id.Index())
+	}
+	if err := lib.Add("a", Prefab{}); !errors.Is(err, ErrDuplicatePrefab) {
+		t.Fatalf("Add duplicate error = %v, want %v", err, ErrDuplicatePrefab)
+	}
+}
+
+func TestPrefabLibrary_LoadRejectsMisspelledAndCaseOnlyFields(t *testing.T) {
+	cases := map[string]string{
+		"misspelled field": `{"mover": {"components": {"velocity": {"DXX": 3}}}}`,
+		"case-only override": `{
+			"mover": {"components": {"velocity": {"DX": 3}}},
+			"fast": {"extends": "mover", "components": {"velocity": {"dx": 9}}}
+		}`,
+	}
+	for name, data := range cases {
+		lib := NewPrefabLibrary(newTestRegistry())
+		if err := lib.Load(strings.NewReader(data)); err == nil {
+			t.Errorf("%s: Load succeeded, want an error", name)
+		}
+	}
+}
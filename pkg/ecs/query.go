@@ -0,0 +1,167 @@
+package ecs
+
+import "reflect"
+
+// componentTypeOf returns the ComponentType reported by the component type C.
+//
+// Components are usually stored as pointers (for example *Transform) while
+// their Type method is declared on the value receiver. Calling Type on a nil
+// pointer would panic, so a fresh zero value is allocated for pointer types.
+func componentTypeOf[C Component]() ComponentType {
+	rt := reflect.TypeFor[C]()
+	if rt.Kind() == reflect.Pointer {
+		return reflect.New(rt.Elem()).Interface().(Component).Type()
+	}
+	var zero C
+	return zero.Type()
+}
+
+// fetch returns the component of type t for an entity as C. It reports false
+// when the entity has no such component or the stored value is not a C.
+func fetch[C Component](w *World, id EntityID, t ComponentType) (C, bool) {
+	c, ok := w.GetComponent(id, t)
+	if !ok {
+		var zero C
+		return zero, false
+	}
+	typed, ok := c.(C)
+	return typed, ok
+}
+
+// Get returns the component of type C attached to an entity.
+//
+// It is the typed counterpart of GetComponent and reports false when the
+// entity has no such component or the stored value is not a C.
+func Get[C Component](w *World, id EntityID) (C, bool) {
+	return fetch[C](w, id, componentTypeOf[C]())
+}
+
+// Query1 iterates all entities that have a component of type A.
+type Query1[A Component] struct {
+	world *World
+	ta    ComponentType
+	mask  Mask
+
+	filters []Filter
+}
+
+// NewQuery1 constructs a query over entities with a component of type A.
+// Optional filters restrict it to recently added or changed components.
+func NewQuery1[A Component](w *World, filters ...Filter) *Query1[A] {
+	ta := componentTypeOf[A]()
+	return &Query1[A]{world: w, ta: ta, mask: MaskFor(ta), filters: filters}
+}
+
+// Each calls fn for every matching entity with its typed component.
+// Entities whose stored component is not of the requested Go type are skipped.
+func (q *Query1[A]) Each(fn func(id EntityID, a A)) {
+	for _, id := range q.world.Find(q.mask) {
+		if !matchesAll(q.world, id, q.filters) {
+			continue
+		}
+		a, okA := fetch[A](q.world, id, q.ta)
+		if !okA {
+			continue
+		}
+		fn(id, a)
+	}
+}
+
+// Query2 iterates all entities that have components of types A and B.
+type Query2[A, B Component] struct {
+	world  *World
+	ta, tb ComponentType
+	mask   Mask
+
+	filters []Filter
+}
+
+// NewQuery2 constructs a query over entities with components of types A and B.
+// Optional filters restrict it to recently added or changed components.
+func NewQuery2[A, B Component](w *World, filters ...Filter) *Query2[A, B] {
+	ta, tb := componentTypeOf[A](), componentTypeOf[B]()
+	return &Query2[A, B]{world: w, ta: ta, tb: tb, mask: MaskFor(ta, tb), filters: filters}
+}
+
+// Each calls fn for every matching entity with its typed components.
+// Entities whose stored components are not of the requested Go types are skipped.
+func (q *Query2[A, B]) Each(fn func(id EntityID, a A, b B)) {
+	for _, id := range q.world.Find(q.mask) {
+		if !matchesAll(q.world, id, q.filters) {
+			continue
+		}
+		a, okA := fetch[A](q.world, id, q.ta)
+		b, okB := fetch[B](q.world, id, q.tb)
+		if !okA || !okB {
+			continue
+		}
+		fn(id, a, b)
+	}
+}
+
+// Query3 iterates all entities that have components of types A, B and C.
+type Query3[A, B, C Component] struct {
+	world      *World
+	ta, tb, tc ComponentType
+	mask       Mask
+
+	filters []Filter
+}
+
+// NewQuery3 constructs a query over entities with components of types A, B and C.
+// Optional filters restrict it to recently added or changed components.
+func NewQuery3[A, B, C Component](w *World, filters ...Filter) *Query3[A, B, C] {
+	ta, tb, tc := componentTypeOf[A](), componentTypeOf[B](), componentTypeOf[C]()
+	return &Query3[A, B, C]{world: w, ta: ta, tb: tb, tc: tc, mask: MaskFor(ta, tb, tc), filters: filters}
+}
+
+// Each calls fn for every matching entity with its typed components.
+// Entities whose stored components are not of the requested Go types are skipped.
+func (q *Query3[A, B, C]) Each(fn func(id EntityID, a A, b B, c C)) {
+	for _, id := range q.world.Find(q.mask) {
+		if !matchesAll(q.world, id, q.filters) {
+			continue
+		}
+		a, okA := fetch[A](q.world, id, q.ta)
+		b, okB := fetch[B](q.world, id, q.tb)
+		c, okC := fetch[C](q.world, id, q.tc)
+		if !okA || !okB || !okC {
+			continue
+		}
+		fn(id, a, b, c)
+	}
+}
+
+// Query4 iterates all entities that have components of types A, B, C and D.
+type Query4[A, B, C, D Component] struct {
+	world          *World
+	ta, tb, tc, td ComponentType
+	mask           Mask
+
+	filters []Filter
+}
+
+// NewQuery4 constructs a query over entities with components of types A, B, C and D.
+// Optional filters restrict it to recently added or changed components.
+func NewQuery4[A, B, C, D Component](w *World, filters ...Filter) *Query4[A, B, C, D] {
+	ta, tb, tc, td := componentTypeOf[A](), componentTypeOf[B](), componentTypeOf[C](), componentTypeOf[D]()
+	return &Query4[A, B, C, D]{world: w, ta: ta, tb: tb, tc: tc, td: td, mask: MaskFor(ta, tb, tc, td), filters: filters}
+}
+
+// Each calls fn for every matching entity with its typed components.
+// Entities whose stored components are not of the requested Go types are skipped.
+func (q *Query4[A, B, C, D]) Each(fn func(id EntityID, a A, b B, c C, d D)) {
+	for _, id := range q.world.Find(q.mask) {
+		if !matchesAll(q.world, id, q.filters) {
+			continue
+		}
+		a, okA := fetch[A](q.world, id, q.ta)
+		b, okB := fetch[B](q.world, id, q.tb)
+		c, okC := fetch[C](q.world, id, q.tc)
+		d, okD := fetch[D](q.world, id, q.td)
+		if !okA || !okB || !okC || !okD {
+			continue
+		}
+		fn(id, a, b, c, d)
+	}
+}
@@ -0,0 +1,84 @@
+package ecs
+
+import "testing"
+
+const (
+	testTypePosition ComponentType = iota
+	testTypeVelocity
+	testTypeTag
+)
+
+type testPosition struct{ X, Y float64 }
+
+func (testPosition) Type() ComponentType { return testTypePosition }
+
+type testVelocity struct{ DX, DY float64 }
+
+func (testVelocity) Type() ComponentType { return testTypeVelocity }
+
+type testTag struct{}
+
+func (testTag) Type() ComponentType { return testTypeTag }
+
+func TestGet_ReturnsTypedComponent(t *testing.T) {
+	w := NewWorld()
+	id := w.NewEntity()
+	w.AddComponent(id, &testPosition{X: 1, Y: 2})
+
+	p, ok := Get[*testPosition](w, id)
+	if !ok {
+		t.Fatalf("Get[*testPosition] ok = false, want true")
+	}
+	if p.X != 1 || p.Y != 2 {
+		t.Fatalf("Get[*testPosition] = %+v, want {1 2}", *p)
+	}
+
+	if _, ok := Get[*testVelocity](w, id); ok {
+		t.Fatalf("Get[*testVelocity] ok = true for entity without velocity")
+	}
+}
+
+func TestQuery2_YieldsOnlyEntitiesWithBothComponents(t *testing.T) {
+	w := NewWorld()
+	moving := w.NewEntity()
+	w.AddComponent(moving, &testPosition{})
+	w.AddComponent(moving, &testVelocity{DX: 2, DY: 3})
+
+	static := w.NewEntity()
+	w.AddComponent(static, &testPosition{X: 5})
+
+	visited := 0
+	NewQuery2[*testPosition, *testVelocity](w).Each(func(id EntityID, p *testPosition, v *testVelocity) {
+		visited++
+		if id != moving {
+			t.Fatalf("query visited entity %v, want only %v", id, moving)
+		}
+		p.X += v.DX
+		p.Y += v.DY
+	})
+
+	if visited != 1 {
+		t.Fatalf("query visited %d entities, want 1", visited)
+	}
+	p, _ := Get[*testPosition](w, moving)
+	if p.X != 2 || p.Y != 3 {
+		t.Fatalf("position after query = %+v, want {2 3}", *p)
+	}
+}
+
+func TestQuery_SkipsComponentsStoredWithDifferentGoType(t *testing.T) {
+	w := NewWorld()
+	id := w.NewEntity()
+	// Stored by value rather than by pointer.
+	w.AddComponent(id, testPosition{})
+
+	NewQuery1[*testPosition](w).Each(func(EntityID, *testPosition) {
+		t.Fatalf("query must skip components that are not *testPosition")
+	})
+
+	count := 0
+	NewQuery1[testPosition](w).Each(func(EntityID, testPosition) { count++ })
+	if count != 1 {
+		t.Fatalf("value query visited %d entities, want 1", count)
+	}
+}
@@ -0,0 +1,68 @@
+package ecs
+
+import "fmt"
+
+// ComponentFactory returns a new, zero-valued component ready to be decoded
+// into. Factories usually return a pointer, for example &Transform{}.
+type ComponentFactory func() Component
+
+// registration binds a component name to its type and factory.
+type registration struct {
+	name    string
+	t       ComponentType
+	factory ComponentFactory
+}
+
+// Registry maps component types to stable names and constructors.
+//
+// Names are used instead of raw ComponentType values in serialized data so
+// that saved snapshots survive reordering of type constants.
+type Registry struct {
+	byName map[string]registration
+	byType map[ComponentType]registration
+}
+
+// NewRegistry constructs an empty component registry.
+func NewRegistry() *Registry {
+	return &Registry{
+		byName: make(map[string]registration),
+		byType: make(map[ComponentType]registration),
+	}
+}
+
+// Register associates a name with a component factory. The ComponentType is
+// taken from the value the factory returns. Register panics if the name or
+// type is already registered, mirroring other registries like gob.Register.
+func (r *Registry) Register(name string, factory ComponentFactory) {
+	t := factory().Type()
+	if _, ok := r.byName[name]; ok {
+		panic(fmt.Sprintf("ecs: component name %q registered twice", name))
+	}
+	if existing, ok := r.byType[t]; ok {
+		panic(fmt.Sprintf("ecs: component type %d registered as both %q and %q", t, existing.name, name))
+	}
+	reg := registration{name: name, t: t, factory: factory}
+	r.byName[name] = reg
+	r.byType[t] = reg
+}
+
+// Name returns the registered name of a component type.
+func (r *Registry) Name(t ComponentType) (string, bool) {
+	reg, ok := r.byType[t]
+	return reg.name, ok
+}
+
+// Type returns the component type registered under a name.
+func (r *Registry) Type(name string) (ComponentType, bool) {
+	reg, ok := r.byName[name]
+	return reg.t, ok
+}
+
+// New constructs a fresh component for a registered name.
+func (r *Registry) New(name string) (Component, bool) {
+	reg, ok := r.byName[name]
+	if !ok {
+		return nil, false
+	}
+	return reg.factory(), true
+}
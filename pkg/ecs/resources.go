@@ -0,0 +1,48 @@
+package ecs
+
+import "reflect"
+
+// Resources are world-level singletons keyed by their Go type, such as the
+// level map, camera, score or random number generator. They let systems
+// reach shared state through the World they already receive instead of
+// having it threaded through by hand.
+//
+// Resources should be inserted and removed outside of parallel system
+// batches; systems running concurrently may only read them.
+
+// resources stores one value per Go type.
+type resources struct {
+	values map[reflect.Type]any
+}
+
+func newResources() *resources {
+	return &resources{values: make(map[reflect.Type]any)}
+}
+
+// SetResource inserts or replaces the resource of type T.
+func SetResource[T any](w *World, v T) {
+	w.checkStructural("SetResource")
+	w.resources.values[reflect.TypeFor[T]()] = v
+}
+
+// GetResource returns the resource of type T, if present.
+func GetResource[T any](w *World) (T, bool) {
+	v, ok := w.resources.values[reflect.TypeFor[T]()]
+	if !ok {
+		var zero T
+		return zero, false
+	}
+	return v.(T), true
+}
+
+// HasResource reports whether a resource of type T is present.
+func HasResource[T any](w *World) bool {
+	_, ok := w.resources.values[reflect.TypeFor[T]()]
+	return ok
+}
+
+// RemoveResource deletes the resource of type T, if present.
+func RemoveResource[T any](w *World) {
+	w.checkStructural("RemoveResource")
+	delete(w.resources.values, reflect.TypeFor[T]())
+}
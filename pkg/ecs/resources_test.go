@@ -0,0 +1,45 @@
+package ecs
+
+import "testing"
+
+type testScore struct{ Points int }
+
+type testTileSize int
+
+func TestResources_SetGetRemoveByType(t *testing.T) {
+	w := NewWorld()
+
+	if _, ok := GetResource[*testScore](w); ok {
+		t.Fatalf("GetResource on empty world ok = true")
+	}
+
+	score := &testScore{}
+	SetResource(w, score)
+	SetResource(w, testTileSize(16))
+
+	got, ok := GetResource[*testScore](w)
+	if !ok || got != score {
+		t.Fatalf("GetResource[*testScore] = %v, %v; want inserted pointer", got, ok)
+	}
+	got.Points += 5
+	if score.Points != 5 {
+		t.Fatalf("pointer resources must be shared, got %d points", score.Points)
+	}
+
+	if size, ok := GetResource[testTileSize](w); !ok || size != 16 {
+		t.Fatalf("GetResource[testTileSize] = %v, %v; want 16", size, ok)
+	}
+
+	SetResource(w, testTileSize(32))
+	if size, _ := GetResource[testTileSize](w); size != 32 {
+		t.Fatalf("SetResource did not replace value, got %v", size)
+	}
+
+	RemoveResource[*testScore](w)
+	if HasResource[*testScore](w) {
+		t.Fatalf("resource still present after RemoveResource")
+	}
+	if !HasResource[testTileSize](w) {
+		t.Fatalf("RemoveResource removed an unrelated resource")
+	}
+}
@@ -0,0 +1,441 @@
+package ecs
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"slices"
+	"sync"
+	"time"
+)
+
+// Stage names a phase of the frame in which a group of systems runs.
+// Stages run in the order they were given to NewScheduler.
+type Stage string
+
+const (
+	StageInput       Stage = "input"
+	StageSimulation  Stage = "simulation"
+	StagePostPhysics Stage = "post_physics"
+	StageRenderPrep  Stage = "render_prep"
+)
+
+// DefaultStages is the stage order used when NewScheduler is called without
+// explicit stages.
+var DefaultStages = []Stage{StageInput, StageSimulation, StagePostPhysics, StageRenderPrep}
+
+var (
+	ErrDuplicateSystem = errors.New("ecs: system already registered")
+	ErrUnknownStage    = errors.New("ecs: unknown stage")
+	ErrUnknownSystem   = errors.New("ecs: ordering refers to unknown system")
+	ErrSystemCycle     = errors.New("ecs: system ordering contains a cycle")
+)
+
+// SystemFunc is the signature of a system run by the Scheduler.
+type SystemFunc func(w *World, dt float64)
+
+// System describes a system registered with a Scheduler.
+//
+// Before and After name other systems this one must run before or after.
+// Within a stage they determine execution order; across stages they must
+// agree with the stage order.
+//
+// Reads and Writes declare which component types the system accesses. When
+// parallel execution is enabled, systems whose accesses do not conflict run
+// concurrently. A system that declares neither is treated as exclusive and
+// never runs alongside another system.
+//
+// EmitsEvents and ReadsEvents declare the event types (see EventTypeOf) the
+// system emits and reads. A system that emits an event type never shares a
+// batch with one that reads it, so the reader sees the events exactly as in
+// a sequential run. Undeclared events are still delivered, but in parallel
+// mode a reader in the same batch only sees them in the following frame.
+//
+// A system that declares access shares the world with the systems it runs
+// alongside, so it may read and modify components in place but must make
+// structural changes (creating or destroying entities, adding or removing
+// components, resources, parents or hooks) through World.Commands. With
+// parallel execution enabled, calling those methods directly panics.
+type System struct {
+	Name   string
+	Stage  Stage
+	Run    SystemFunc
+	Before []string
+	After  []string
+	Reads  []ComponentType
+	Writes []ComponentType
+
+	EmitsEvents []EventType
+	ReadsEvents []EventType
+}
+
+// EventType identifies an event type in System.EmitsEvents and ReadsEvents.
+type EventType = reflect.Type
+
+// EventTypeOf returns the EventType of events of type E.
+func EventTypeOf[E any]() EventType {
+	return reflect.TypeFor[E]()
+}
+
+// exclusive reports whether the system declared no component or event
+// access.
+func (sys System) exclusive() bool {
+	return len(sys.Reads) == 0 && len(sys.Writes) == 0 &&
+		len(sys.EmitsEvents) == 0 && len(sys.ReadsEvents) == 0
+}
+
+// SystemTiming reports how long a system took during the last Run.
+type SystemTiming struct {
+	Name     string
+	Stage    Stage
+	Duration time.Duration
+}
+
+// scheduledSystem is a System plus its runtime state.
+type scheduledSystem struct {
+	System
+	enabled bool
+	last    time.Duration
+
+	reads, writes Mask
+}
+
+// conflicts reports whether two systems may not run at the same time because
+// one of them writes a component type the other reads or writes, or emits an
+// event type the other reads.
+func (a *scheduledSystem) conflicts(b *scheduledSystem) bool {
+	if a.exclusive() || b.exclusive() {
+		return true
+	}
+	return a.writes.Intersects(b.writes) || a.writes.Intersects(b.reads) || b.writes.Intersects(a.reads) ||
+		sharesEventType(a.EmitsEvents, b.ReadsEvents) || sharesEventType(b.EmitsEvents, a.ReadsEvents)
+}
+
+// sharesEventType reports whether the two lists have an event type in common.
+func sharesEventType(a, b []EventType) bool {
+	for _, t := range a {
+		if slices.Contains(b, t) {
+			return true
+		}
+	}
+	return false
+}
+
+// Scheduler runs registered systems stage by stage in a dependency-respecting
+// order. After every stage the world's command buffer is flushed, making the
+// stage boundary the sync point for deferred structural changes.
+//
+// With parallel execution enabled, each stage is split into batches of
+// systems that neither depend on nor conflict with each other, and the
+// systems of a batch run on separate goroutines. Every system in a batch
+// records commands and events into private buffers; the buffers are merged in
+// the sequential execution order, so results match a sequential run as long
+// as systems declare the components and events they access.
+type Scheduler struct {
+	stages   []Stage
+	systems  []*scheduledSystem
+	byName   map[string]*scheduledSystem
+	parallel bool
+
+	// order caches the sorted systems per stage; nil means it must be rebuilt.
+	order map[Stage][]*scheduledSystem
+	// batches caches the parallel batches per stage, built alongside order.
+	batches map[Stage][][]*scheduledSystem
+}
+
+// NewScheduler constructs a scheduler with the given stages, or
+// DefaultStages when none are given.
+func NewScheduler(stages ...Stage) *Scheduler {
+	if len(stages) == 0 {
+		stages = DefaultStages
+	}
+	return &Scheduler{
+		stages: append([]Stage(nil), stages...),
+		byName: make(map[string]*scheduledSystem),
+	}
+}
+
+// Add registers a system. Systems start enabled.
+func (s *Scheduler) Add(sys System) error {
+	if _, ok := s.byName[sys.Name]; ok {
+		return fmt.Errorf("%w: %q", ErrDuplicateSystem, sys.Name)
+	}
+	if s.stageIndex(sys.Stage) < 0 {
+		return fmt.Errorf("%w: %q", ErrUnknownStage, sys.Stage)
+	}
+	entry := &scheduledSystem{
+		System:  sys,
+		enabled: true,
+		reads:   MaskFor(sys.Reads...),
+		writes:  MaskFor(sys.Writes...),
+	}
+	s.systems = append(s.systems, entry)
+	s.byName[sys.Name] = entry
+	s.order = nil
+	return nil
+}
+
+// SetEnabled enables or disables a system at runtime. It reports whether a
+// system with that name exists.
+func (s *Scheduler) SetEnabled(name string, enabled bool) bool {
+	entry, ok := s.byName[name]
+	if !ok {
+		return false
+	}
+	entry.enabled = enabled
+	return true
+}
+
+// SetParallel enables or disables concurrent execution of non-conflicting
+// systems within a stage.
+func (s *Scheduler) SetParallel(parallel bool) {
+	s.parallel = parallel
+}
+
+// Enabled reports whether the named system exists and is enabled.
+func (s *Scheduler) Enabled(name string) bool {
+	entry, ok := s.byName[name]
+	return ok && entry.enabled
+}
+
+// Build validates ordering constraints and computes the execution order.
+// Run calls it automatically; calling it up front surfaces configuration
+// errors early.
+func (s *Scheduler) Build() error {
+	if s.order != nil {
+		return nil
+	}
+
+	edges := make(map[*scheduledSystem][]*scheduledSystem)
+	indegree := make(map[*scheduledSystem]int)
+	addEdge := func(from, to *scheduledSystem) error {
+		fromStage, toStage := s.stageIndex(from.Stage), s.stageIndex(to.Stage)
+		if fromStage > toStage {
+			return fmt.Errorf("%w: %q must run before %q but its stage %q runs after %q",
+				ErrSystemCycle, from.Name, to.Name, from.Stage, to.Stage)
+		}
+		if fromStage == toStage {
+			edges[from] = append(edges[from], to)
+			indegree[to]++
+		}
+		return nil
+	}
+
+	for _, sys := range s.systems {
+		for _, name := range sys.Before {
+			other, ok := s.byName[name]
+			if !ok {
+				return fmt.Errorf("%w: %q (before of %q)", ErrUnknownSystem, name, sys.Name)
+			}
+			if err := addEdge(sys, other); err != nil {
+				return err
+			}
+		}
+		for _, name := range sys.After {
+			other, ok := s.byName[name]
+			if !ok {
+				return fmt.Errorf("%w: %q (after of %q)", ErrUnknownSystem, name, sys.Name)
+			}
+			if err := addEdge(other, sys); err != nil {
+				return err
+			}
+		}
+	}
+
+	order := make(map[Stage][]*scheduledSystem, len(s.stages))
+	batches := make(map[Stage][][]*scheduledSystem, len(s.stages))
+	for _, stage := range s.stages {
+		// Kahn's algorithm; ready systems are taken in registration order so
+		// the result is deterministic.
+		var pending []*scheduledSystem
+		for _, sys := range s.systems {
+			if sys.Stage == stage {
+				pending = append(pending, sys)
+			}
+		}
+		sorted := make([]*scheduledSystem, 0, len(pending))
+		for len(pending) > 0 {
+			next := -1
+			for i, sys := range pending {
+				if indegree[sys] == 0 {
+					next = i
+					break
+				}
+			}
+			if next < 0 {
+				return fmt.Errorf("%w in stage %q", ErrSystemCycle, stage)
+			}
+			sys := pending[next]
+			pending = append(pending[:next], pending[next+1:]...)
+			sorted = append(sorted, sys)
+			for _, to := range edges[sys] {
+				indegree[to]--
+			}
+		}
+		order[stage] = sorted
+		batches[stage] = batchSystems(sorted, edges)
+	}
+
+	s.order = order
+	s.batches = batches
+	return nil
+}
+
+// batchSystems groups sorted systems into batches that can run concurrently.
+// A system is placed after every earlier system it depends on or conflicts
+// with, so executing the batches in order is equivalent to executing sorted
+// sequentially.
+func batchSystems(sorted []*scheduledSystem, edges map[*scheduledSystem][]*scheduledSystem) [][]*scheduledSystem {
+	level := make(map[*scheduledSystem]int, len(sorted))
+	var batches [][]*scheduledSystem
+	for i, sys := range sorted {
+		lvl := 0
+		for _, earlier := range sorted[:i] {
+			if earlier.conflicts(sys) || dependsOn(edges, earlier, sys) {
+				if l := level[earlier] + 1; l > lvl {
+					lvl = l
+				}
+			}
+		}
+		level[sys] = lvl
+		if lvl == len(batches) {
+			batches = append(batches, nil)
+		}
+		batches[lvl] = append(batches[lvl], sys)
+	}
+	return batches
+}
+
+// dependsOn reports whether there is a direct ordering edge from -> to.
+func dependsOn(edges map[*scheduledSystem][]*scheduledSystem, from, to *scheduledSystem) bool {
+	for _, next := range edges[from] {
+		if next == to {
+			return true
+		}
+	}
+	return false
+}
+
+// Run executes all enabled systems for one frame. It starts a new event
+// frame and change-detection tick (see AdvanceEvents and AdvanceTick) before
+// the first stage. Run panics if the ordering constraints are invalid; use
+// Build to check them without running.
+func (s *Scheduler) Run(w *World, dt float64) {
+	if err := s.Build(); err != nil {
+		panic(err)
+	}
+	w.AdvanceEvents()
+	w.AdvanceTick()
+	for _, stage := range s.stages {
+		if s.parallel {
+			for _, batch := range s.batches[stage] {
+				runBatch(w, batch, dt)
+			}
+		} else {
+			for _, sys := range s.order[stage] {
+				runSystem(w, sys, dt)
+			}
+		}
+		w.FlushCommands()
+	}
+}
+
+// runSystem runs a single system, recording its duration.
+func runSystem(w *World, sys *scheduledSystem, dt float64) {
+	if !sys.enabled {
+		sys.last = 0
+		return
+	}
+	start := time.Now()
+	sys.Run(w, dt)
+	sys.last = time.Since(start)
+}
+
+// runBatch runs the systems of a batch concurrently. Each system gets its
+// own view of the world (see systemView); the views are merged back in batch
+// order once all systems have finished. Exclusive systems always form a batch
+// of their own and run directly on w. Systems that declare access use a view
+// even when alone, so the rules for structural changes do not depend on how
+// the systems happen to be batched.
+func runBatch(w *World, batch []*scheduledSystem, dt float64) {
+	if len(batch) == 1 && batch[0].exclusive() {
+		runSystem(w, batch[0], dt)
+		return
+	}
+	views := make([]*World, len(batch))
+	panics := make([]any, len(batch))
+	var wg sync.WaitGroup
+	for i, sys := range batch {
+		view := w.systemView(sys.Name)
+		views[i] = view
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			// Re-raised below, so a failing system panics on the caller's
+			// goroutine instead of crashing the process.
+			defer func() { panics[i] = recover() }()
+			runSystem(view, sys, dt)
+		}()
+	}
+	wg.Wait()
+	for _, p := range panics {
+		if p != nil {
+			panic(p)
+		}
+	}
+	for _, view := range views {
+		w.mergeView(view)
+	}
+}
+
+// systemView returns a view of the world for a system running in a parallel
+// batch. It shares all entity, component and event storage with w but records
+// deferred commands and emitted events privately, so concurrently running
+// systems never write to shared buffers. Structural changes made directly on
+// a view would be lost or race, so they panic (see checkStructural).
+func (w *World) systemView(system string) *World {
+	view := *w
+	view.commands = NewCommandBuffer()
+	view.staged = &stagedEvents{}
+	view.viewOf = system
+	return &view
+}
+
+// checkStructural panics if w is the view of a system running in a parallel
+// batch. op names the structural method that was called.
+func (w *World) checkStructural(op string) {
+	if w.viewOf != "" {
+		panic(fmt.Sprintf("ecs: system %q called World.%s while running in a parallel batch; record structural changes with World.Commands instead", w.viewOf, op))
+	}
+}
+
+// mergeView appends the commands and events recorded by a system view to w.
+func (w *World) mergeView(view *World) {
+	w.commands.commands = append(w.commands.commands, view.commands.commands...)
+	view.staged.emitTo(w.events)
+}
+
+// Timings returns the duration of every system during the last Run, in
+// execution order. Disabled systems report zero.
+func (s *Scheduler) Timings() []SystemTiming {
+	if err := s.Build(); err != nil {
+		return nil
+	}
+	timings := make([]SystemTiming, 0, len(s.systems))
+	for _, stage := range s.stages {
+		for _, sys := range s.order[stage] {
+			timings = append(timings, SystemTiming{Name: sys.Name, Stage: sys.Stage, Duration: sys.last})
+		}
+	}
+	return timings
+}
+
+// stageIndex returns the position of a stage, or -1 if unknown.
+func (s *Scheduler) stageIndex(stage Stage) int {
+	for i, st := range s.stages {
+		if st == stage {
+			return i
+		}
+	}
+	return -1
+}
@@ -0,0 +1,229 @@
+package ecs
+
+import (
+	"reflect"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+const testTypeLifetime ComponentType = 10
+
+type testLifetime struct{ Remaining float64 }
+
+func (testLifetime) Type() ComponentType { return testTypeLifetime }
+
+// newParallelTestScheduler registers three systems with disjoint component
+// access plus one that depends on all of them.
+func newParallelTestScheduler(parallel bool) *Scheduler {
+	s := NewScheduler()
+	s.SetParallel(parallel)
+	systems := []System{
+		{
+			Name:   "integrate",
+			Stage:  StageSimulation,
+			Reads:  []ComponentType{testTypeVelocity},
+			Writes: []ComponentType{testTypePosition},
+			Run: func(w *World, dt float64) {
+				NewQuery2[*testPosition, *testVelocity](w).Each(func(_ EntityID, p *testPosition, v *testVelocity) {
+					p.X += v.DX * dt
+					p.Y += v.DY * dt
+				})
+			},
+		},
+		{
+			Name:   "lifetime",
+			Stage:  StageSimulation,
+			Writes: []ComponentType{testTypeLifetime},
+			Run: func(w *World, dt float64) {
+				NewQuery1[*testLifetime](w).Each(func(id EntityID, l *testLifetime) {
+					l.Remaining -= dt
+					if l.Remaining <= 0 {
+						w.Commands().Destroy(id)
+					}
+				})
+			},
+		},
+		{
+			Name:   "spawner",
+			Stage:  StageSimulation,
+			Reads:  []ComponentType{testTypeTag},
+			Writes: []ComponentType{testTypeTag},
+			Run: func(w *World, _ float64) {
+				NewQuery1[testTag](w).Each(func(EntityID, testTag) {
+					w.Commands().Spawn(&testPosition{}, &testVelocity{DX: 1}, &testLifetime{Remaining: 0.05})
+				})
+			},
+		},
+		{
+			Name:   "accelerate",
+			Stage:  StageSimulation,
+			After:  []string{"integrate"},
+			Writes: []ComponentType{testTypeVelocity},
+			Run: func(w *World, _ float64) {
+				NewQuery1[*testVelocity](w).Each(func(_ EntityID, v *testVelocity) {
+					v.DX *= 1.01
+				})
+			},
+		},
+	}
+	for _, sys := range systems {
+		if err := s.Add(sys); err != nil {
+			panic(err)
+		}
+	}
+	return s
+}
+
+func newParallelTestWorld() *World {
+	w := NewWorld()
+	for i := 0; i < 200; i++ {
+		id := w.NewEntity()
+		w.AddComponent(id, &testPosition{X: float64(i)})
+		w.AddComponent(id, &testVelocity{DX: 1, DY: float64(i % 7)})
+		w.AddComponent(id, &testLifetime{Remaining: float64(i%13) * 0.02})
+		if i%10 == 0 {
+			w.AddComponent(id, testTag{})
+		}
+	}
+	return w
+}
+
+// worldState captures every entity with its position and lifetime.
+func worldState(w *World) map[EntityID][2]float64 {
+	state := make(map[EntityID][2]float64)
+	for _, id := range w.Find(MaskFor(testTypePosition)) {
+		p, _ := Get[*testPosition](w, id)
+		remaining := -1.0
+		if l, ok := Get[*testLifetime](w, id); ok {
+			remaining = l.Remaining
+		}
+		state[id] = [2]float64{p.X + p.Y, remaining}
+	}
+	return state
+}
+
+func TestScheduler_ParallelExecutionMatchesSequential(t *testing.T) {
+	sequential, parallel := newParallelTestWorld(), newParallelTestWorld()
+	seqSched, parSched := newParallelTestScheduler(false), newParallelTestScheduler(true)
+
+	for frame := 0; frame < 30; frame++ {
+		seqSched.Run(sequential, 0.016)
+		parSched.Run(parallel, 0.016)
+	}
+
+	if !reflect.DeepEqual(worldState(sequential), worldState(parallel)) {
+		t.Fatalf("parallel run diverged from sequential run")
+	}
+}
+
+func TestScheduler_RunsNonConflictingSystemsConcurrently(t *testing.T) {
+	s := NewScheduler()
+	s.SetParallel(true)
+
+	var running, peak atomic.Int32
+	work := func(*World, float64) {
+		n := running.Add(1)
+		for {
+			p := peak.Load()
+			if n <= p || peak.CompareAndSwap(p, n) {
+				break
+			}
+		}
+		time.Sleep(20 * time.Millisecond)
+		running.Add(-1)
+	}
+	_ = s.Add(System{Name: "a", Stage: StageSimulation, Writes: []ComponentType{testTypePosition}, Run: work})
+	_ = s.Add(System{Name: "b", Stage: StageSimulation, Writes: []ComponentType{testTypeVelocity}, Run: work})
+	// c conflicts with a and must wait for it.
+	_ = s.Add(System{Name: "c", Stage: StageSimulation, Reads: []ComponentType{testTypePosition}, Run: work})
+
+	s.Run(NewWorld(), 0)
+
+	if got := peak.Load(); got != 2 {
+		t.Fatalf("peak concurrency = %d, want 2", got)
+	}
+}
+
+func TestBatchSystems_SeparatesConflictsAndDependencies(t *testing.T) {
+	s := NewScheduler()
+	noop := func(*World, float64) {}
+	_ = s.Add(System{Name: "exclusive", Stage: StageSimulation, Run: noop})
+	_ = s.Add(System{Name: "a", Stage: StageSimulation, Writes: []ComponentType{1}, Run: noop})
+	_ = s.Add(System{Name: "b", Stage: StageSimulation, Reads: []ComponentType{2}, Run: noop})
+	_ = s.Add(System{Name: "c", Stage: StageSimulation, Reads: []ComponentType{1}, Run: noop})
+	_ = s.Add(System{Name: "d", Stage: StageSimulation, Reads: []ComponentType{2}, After: []string{"b"}, Run: noop})
+	if err := s.Build(); err != nil {
+		t.Fatalf("Build failed: %v", err)
+	}
+
+	var got [][]string
+	for _, batch := range s.batches[StageSimulation] {
+		var names []string
+		for _, sys := range batch {
+			names = append(names, sys.Name)
+		}
+		got = append(got, names)
+	}
+	want := [][]string{{"exclusive"}, {"a", "b"}, {"c", "d"}}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("batches = %v, want %v", got, want)
+	}
+}
+
+func TestScheduler_ParallelSystemsMustNotChangeStructureDirectly(t *testing.T) {
+	s := NewScheduler()
+	s.SetParallel(true)
+	_ = s.Add(System{
+		Name:   "spawner",
+		Stage:  StageSimulation,
+		Writes: []ComponentType{testTypePosition},
+		Run:    func(w *World, _ float64) { w.NewEntity() },
+	})
+
+	defer func() {
+		msg, _ := recover().(string)
+		if !strings.Contains(msg, `"spawner"`) || !strings.Contains(msg, "World.Commands") {
+			t.Fatalf("panic = %q, want a message naming the system and World.Commands", msg)
+		}
+	}()
+	s.Run(NewWorld(), 0)
+	t.Fatal("direct NewEntity from a parallel system did not panic")
+}
+
+func TestScheduler_ParallelEventsMatchSequential(t *testing.T) {
+	run := func(parallel bool) []int {
+		s := NewScheduler()
+		s.SetParallel(parallel)
+		var reader EventReader[testHit]
+		var seen []int
+		_ = s.Add(System{
+			Name:        "emitter",
+			Stage:       StageSimulation,
+			Writes:      []ComponentType{testTypePosition},
+			EmitsEvents: []EventType{EventTypeOf[testHit]()},
+			Run:         func(w *World, _ float64) { Emit(w, testHit{}) },
+		})
+		_ = s.Add(System{
+			Name:        "reader",
+			Stage:       StageSimulation,
+			Writes:      []ComponentType{testTypeVelocity},
+			ReadsEvents: []EventType{EventTypeOf[testHit]()},
+			Run:         func(w *World, _ float64) { seen = append(seen, len(reader.Read(w))) },
+		})
+		w := NewWorld()
+		for frame := 0; frame < 3; frame++ {
+			s.Run(w, 0)
+		}
+		return seen
+	}
+
+	sequential, parallel := run(false), run(true)
+	if !reflect.DeepEqual(sequential, []int{1, 1, 1}) {
+		t.Fatalf("sequential reader saw %v events per frame, want [1 1 1]", sequential)
+	}
+	if !reflect.DeepEqual(parallel, sequential) {
+		t.Fatalf("parallel reader saw %v events per frame, sequential %v", parallel, sequential)
+	}
+}
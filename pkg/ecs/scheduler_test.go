@@ -0,0 +1,124 @@
+package ecs
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func recordingSystem(name string, stage Stage, log *[]string) System {
+	return System{
+		Name:  name,
+		Stage: stage,
+		Run:   func(*World, float64) { *log = append(*log, name) },
+	}
+}
+
+func TestScheduler_RunsStagesInOrderAndHonoursConstraints(t *testing.T) {
+	var log []string
+	s := NewScheduler()
+
+	render := recordingSystem("render_prep", StageRenderPrep, &log)
+	movement := recordingSystem("movement", StageSimulation, &log)
+	intent := recordingSystem("intent", StageInput, &log)
+	intent.After = []string{"poll"}
+	poll := recordingSystem("poll", StageInput, &log)
+	collisions := recordingSystem("collisions", StageSimulation, &log)
+	collisions.Before = []string{"movement"}
+
+	for _, sys := range []System{render, movement, intent, poll, collisions} {
+		if err := s.Add(sys); err != nil {
+			t.Fatalf("Add(%q) failed: %v", sys.Name, err)
+		}
+	}
+
+	s.Run(NewWorld(), 0.016)
+
+	want := []string{"poll", "intent", "collisions", "movement", "render_prep"}
+	if !reflect.DeepEqual(log, want) {
+		t.Fatalf("execution order = %v, want %v", log, want)
+	}
+
+	timings := s.Timings()
+	if len(timings) != len(want) {
+		t.Fatalf("Timings() returned %d entries, want %d", len(timings), len(want))
+	}
+	for i, timing := range timings {
+		if timing.Name != want[i] {
+			t.Fatalf("Timings()[%d].Name = %q, want %q", i, timing.Name, want[i])
+		}
+	}
+}
+
+func TestScheduler_DisabledSystemsAreSkipped(t *testing.T) {
+	var log []string
+	s := NewScheduler()
+	_ = s.Add(recordingSystem("a", StageSimulation, &log))
+	_ = s.Add(recordingSystem("b", StageSimulation, &log))
+
+	if !s.SetEnabled("a", false) {
+		t.Fatalf("SetEnabled on registered system returned false")
+	}
+	if s.SetEnabled("missing", false) {
+		t.Fatalf("SetEnabled on unknown system returned true")
+	}
+	s.Run(NewWorld(), 0)
+	if !reflect.DeepEqual(log, []string{"b"}) {
+		t.Fatalf("executed %v, want [b]", log)
+	}
+
+	s.SetEnabled("a", true)
+	log = nil
+	s.Run(NewWorld(), 0)
+	if !reflect.DeepEqual(log, []string{"a", "b"}) {
+		t.Fatalf("executed %v after re-enabling, want [a b]", log)
+	}
+}
+
+func TestScheduler_FlushesCommandsBetweenStages(t *testing.T) {
+	w := NewWorld()
+	s := NewScheduler()
+	_ = s.Add(System{Name: "spawn", Stage: StageSimulation, Run: func(w *World, _ float64) {
+		w.Commands().Spawn(&testPosition{})
+	}})
+	seen := -1
+	_ = s.Add(System{Name: "count", Stage: StagePostPhysics, Run: func(w *World, _ float64) {
+		seen = len(w.Find(MaskFor(testTypePosition)))
+	}})
+
+	s.Run(w, 0)
+	if seen != 1 {
+		t.Fatalf("post-physics stage saw %d spawned entities, want 1", seen)
+	}
+}
+
+func TestScheduler_BuildRejectsInvalidOrdering(t *testing.T) {
+	noop := func(*World, float64) {}
+
+	s := NewScheduler()
+	_ = s.Add(System{Name: "a", Stage: StageSimulation, Run: noop, After: []string{"b"}})
+	_ = s.Add(System{Name: "b", Stage: StageSimulation, Run: noop, After: []string{"a"}})
+	if err := s.Build(); !errors.Is(err, ErrSystemCycle) {
+		t.Fatalf("Build() with cycle = %v, want ErrSystemCycle", err)
+	}
+
+	s = NewScheduler()
+	_ = s.Add(System{Name: "late", Stage: StageRenderPrep, Run: noop, Before: []string{"early"}})
+	_ = s.Add(System{Name: "early", Stage: StageInput, Run: noop})
+	if err := s.Build(); !errors.Is(err, ErrSystemCycle) {
+		t.Fatalf("Build() with cross-stage contradiction = %v, want ErrSystemCycle", err)
+	}
+
+	s = NewScheduler()
+	_ = s.Add(System{Name: "a", Stage: StageSimulation, Run: noop, After: []string{"ghost"}})
+	if err := s.Build(); !errors.Is(err, ErrUnknownSystem) {
+		t.Fatalf("Build() with unknown dependency = %v, want ErrUnknownSystem", err)
+	}
+
+	if err := s.Add(System{Name: "a", Stage: StageSimulation, Run: noop}); !errors.Is(err, ErrDuplicateSystem) {
+		t.Fatalf("Add duplicate = %v, want ErrDuplicateSystem", err)
+	}
+	if err := s.Add(System{Name: "x", Stage: "nowhere", Run: noop}); !errors.Is(err, ErrUnknownStage) {
+		t.Fatalf("Add with unknown stage = %v, want ErrUnknownStage", err)
+	}
+}
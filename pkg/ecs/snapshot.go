@@ -0,0 +1,343 @@
+package ecs
+
+import (
+	"bufio"
+	"bytes"
+	"compress/flate"
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+)
+
+var (
+	ErrUnregisteredComponent = errors.New("ecs: component type is not registered")
+	ErrInvalidSnapshot       = errors.New("ecs: invalid snapshot")
+)
+
+// Snapshot is a self-contained copy of all entities and components of a
+// World. Component values are stored as JSON using their registered names,
+// so a snapshot is unaffected by later mutation of the live world.
+type Snapshot struct {
+	// Slots holds the generation of every entity slot; index 0 is unused.
+	Slots []uint32 `json:"slots"`
+	// Free lists recyclable slot indices in the order they will be reused.
+	Free     []uint32         `json:"free"`
+	Entities []EntitySnapshot `json:"entities"`
+	// Parents lists parent/child links; each parent's children appear in
+	// attach order.
+	Parents []ParentLink `json:"parents,omitempty"`
+}
+
+// ParentLink records that Child is attached to Parent.
+type ParentLink struct {
+	Child  EntityID `json:"child"`
+	Parent EntityID `json:"parent"`
+}
+
+// EntitySnapshot holds a single live entity and its components.
+type EntitySnapshot struct {
+	ID         EntityID            `json:"id"`
+	Components []ComponentSnapshot `json:"components"`
+}
+
+// ComponentSnapshot is a named, JSON-encoded component value.
+type ComponentSnapshot struct {
+	Type string          `json:"type"`
+	Data json.RawMessage `json:"data"`
+}
+
+// Snapshot captures the world's entities and components. Every component
+// type present in the world must be registered in reg. Entities appear in
+// slot order and components in ascending ComponentType order, so equal worlds
+// produce identical snapshots.
+func (w *World) Snapshot(reg *Registry) (*Snapshot, error) {
+	snap := &Snapshot{
+		Slots:    make([]uint32, len(w.entities)),
+		Free:     append([]uint32{}, w.free...),
+		Entities: make([]EntitySnapshot, 0),
+	}
+	for index, e := range w.entities {
+		snap.Slots[index] = e.generation
+		if !e.alive {
+			continue
+		}
+		id := newEntityID(uint32(index), e.generation)
+		es := EntitySnapshot{ID: id, Components: make([]ComponentSnapshot, 0)}
+		for _, t := range e.mask.Types() {
+			name, ok := reg.Name(t)
+			if !ok {
+				return nil, fmt.Errorf("%w: %d", ErrUnregisteredComponent, t)
+			}
+			c, _ := w.GetComponent(id, t)
+			data, err := json.Marshal(c)
+			if err != nil {
+				return nil, fmt.Errorf("ecs: encoding %q of entity %d: %w", name, id, err)
+			}
+			es.Components = append(es.Components, ComponentSnapshot{Type: name, Data: data})
+		}
+		snap.Entities = append(snap.Entities, es)
+		for _, child := range w.Children(id) {
+			snap.Parents = append(snap.Parents, ParentLink{Child: child, Parent: id})
+		}
+	}
+	return snap, nil
+}
+
+// Restore replaces all entities and components of the world with the
+// contents of the snapshot. Entity IDs, generations and the slot free list
+// are restored exactly, so handles taken before the snapshot stay valid. On
+// error the world is left unchanged. Pending deferred commands are dropped.
+// Registered hooks are kept but do not run for the swapped-in components, so
+// derived structures maintained by hooks must be rebuilt by the caller.
+func (w *World) Restore(reg *Registry, snap *Snapshot) error {
+	w.checkStructural("Restore")
+	restored := NewWorld()
+	restored.tick = w.tick
+	if len(snap.Slots) == 0 {
+		return fmt.Errorf("%w: missing slot table", ErrInvalidSnapshot)
+	}
+	restored.entities = make([]Entity, len(snap.Slots))
+	for index, gen := range snap.Slots {
+		restored.entities[index].generation = gen
+	}
+	for _, index := range snap.Free {
+		if index == 0 || int(index) >= len(snap.Slots) {
+			return fmt.Errorf("%w: free slot %d out of range", ErrInvalidSnapshot, index)
+		}
+	}
+	restored.free = append([]uint32(nil), snap.Free...)
+
+	for _, es := range snap.Entities {
+		index := es.ID.Index()
+		if index == 0 || int(index) >= len(restored.entities) {
+			return fmt.Errorf("%w: entity %d out of range", ErrInvalidSnapshot, es.ID)
+		}
+		e := &restored.entities[index]
+		if e.alive || e.generation != es.ID.Generation() {
+			return fmt.Errorf("%w: entity %d does not match slot table", ErrInvalidSnapshot, es.ID)
+		}
+		e.alive = true
+		for _, cs := range es.Components {
+			c, ok := reg.New(cs.Type)
+			if !ok {
+				return fmt.Errorf("%w: %q", ErrUnregisteredComponent, cs.Type)
+			}
+			if err := json.Unmarshal(cs.Data, c); err != nil {
+				return fmt.Errorf("ecs: decoding %q of entity %d: %w", cs.Type, es.ID, err)
+			}
+			restored.AddComponent(es.ID, c)
+		}
+	}
+	// The free list must name every dead slot exactly once: a duplicate would
+	// hand out the same ID twice and a missing slot would never be reused.
+	inFree := make([]bool, len(restored.entities))
+	for _, index := range restored.free {
+		if restored.entities[index].alive {
+			return fmt.Errorf("%w: free slot %d holds a live entity", ErrInvalidSnapshot, index)
+		}
+		if inFree[index] {
+			return fmt.Errorf("%w: free slot %d listed twice", ErrInvalidSnapshot, index)
+		}
+		inFree[index] = true
+	}
+	for index := 1; index < len(restored.entities); index++ {
+		if !restored.entities[index].alive && !inFree[index] {
+			return fmt.Errorf("%w: dead slot %d missing from free list", ErrInvalidSnapshot, index)
+		}
+	}
+	for _, link := range snap.Parents {
+		if err := restored.SetParent(link.Child, link.Parent); err != nil {
+			return fmt.Errorf("%w: %v", ErrInvalidSnapshot, err)
+		}
+	}
+
+	w.entities = restored.entities
+	w.free = restored.free
+	w.stores = restored.stores
+	w.hierarchy = restored.hierarchy
+	w.commands = NewCommandBuffer()
+	return nil
+}
+
+// WriteJSON writes the snapshot as indented JSON.
+func (s *Snapshot) WriteJSON(out io.Writer) error {
+	enc := json.NewEncoder(out)
+	enc.SetIndent("", "  ")
+	return enc.Encode(s)
+}
+
+// ReadSnapshotJSON reads a snapshot written by WriteJSON.
+func ReadSnapshotJSON(in io.Reader) (*Snapshot, error) {
+	var snap Snapshot
+	if err := json.NewDecoder(in).Decode(&snap); err != nil {
+		return nil, err
+	}
+	return &snap, nil
+}
+
+// binaryMagic prefixes every binary snapshot; the last byte is the format
+// version.
+var binaryMagic = []byte{'T', 'N', 'K', 'S', 1}
+
+// maxBinaryLength bounds any single length read from a binary snapshot so
+// corrupt input cannot trigger huge allocations.
+const maxBinaryLength = 1 << 24
+
+// WriteBinary writes the snapshot in a compact binary form: a magic header
+// followed by a deflate-compressed stream of varint-framed fields. Component
+// names are written once in a table and referenced by index.
+func (s *Snapshot) WriteBinary(out io.Writer) error {
+	if _, err := out.Write(binaryMagic); err != nil {
+		return err
+	}
+	zw, err := flate.NewWriter(out, flate.BestCompression)
+	if err != nil {
+		return err
+	}
+	bw := bufio.NewWriter(zw)
+	putUvarint := func(v uint64) {
+		var buf [binary.MaxVarintLen64]byte
+		n := binary.PutUvarint(buf[:], v)
+		bw.Write(buf[:n])
+	}
+	putBytes := func(b []byte) {
+		putUvarint(uint64(len(b)))
+		bw.Write(b)
+	}
+
+	putUvarint(uint64(len(s.Slots)))
+	for _, gen := range s.Slots {
+		putUvarint(uint64(gen))
+	}
+	putUvarint(uint64(len(s.Free)))
+	for _, index := range s.Free {
+		putUvarint(uint64(index))
+	}
+
+	names := make([]string, 0)
+	nameIndex := make(map[string]int)
+	for _, es := range s.Entities {
+		for _, cs := range es.Components {
+			if _, ok := nameIndex[cs.Type]; !ok {
+				nameIndex[cs.Type] = len(names)
+				names = append(names, cs.Type)
+			}
+		}
+	}
+	putUvarint(uint64(len(names)))
+	for _, name := range names {
+		putBytes([]byte(name))
+	}
+
+	putUvarint(uint64(len(s.Entities)))
+	for _, es := range s.Entities {
+		putUvarint(uint64(es.ID))
+		putUvarint(uint64(len(es.Components)))
+		for _, cs := range es.Components {
+			putUvarint(uint64(nameIndex[cs.Type]))
+			putBytes(cs.Data)
+		}
+	}
+
+	putUvarint(uint64(len(s.Parents)))
+	for _, link := range s.Parents {
+		putUvarint(uint64(link.Child))
+		putUvarint(uint64(link.Parent))
+	}
+
+	if err := bw.Flush(); err != nil {
+		return err
+	}
+	return zw.Close()
+}
+
+// ReadSnapshotBinary reads a snapshot written by WriteBinary.
+func ReadSnapshotBinary(in io.Reader) (*Snapshot, error) {
+	header := make([]byte, len(binaryMagic))
+	if _, err := io.ReadFull(in, header); err != nil {
+		return nil, err
+	}
+	if !bytes.Equal(header, binaryMagic) {
+		return nil, fmt.Errorf("%w: bad header", ErrInvalidSnapshot)
+	}
+	br := bufio.NewReader(flate.NewReader(in))
+
+	var readErr error
+	getUvarint := func() uint64 {
+		if readErr != nil {
+			return 0
+		}
+		v, err := binary.ReadUvarint(br)
+		if err != nil {
+			readErr = err
+		}
+		return v
+	}
+	getLength := func() int {
+		n := getUvarint()
+		if readErr == nil && n > maxBinaryLength {
+			readErr = fmt.Errorf("%w: length %d too large", ErrInvalidSnapshot, n)
+		}
+		if readErr != nil {
+			return 0
+		}
+		return int(n)
+	}
+	getBytes := func() []byte {
+		b := make([]byte, getLength())
+		if readErr == nil {
+			_, readErr = io.ReadFull(br, b)
+		}
+		return b
+	}
+
+	snap := &Snapshot{}
+	snap.Slots = make([]uint32, getLength())
+	for i := range snap.Slots {
+		snap.Slots[i] = uint32(getUvarint())
+	}
+	snap.Free = make([]uint32, getLength())
+	for i := range snap.Free {
+		snap.Free[i] = uint32(getUvarint())
+	}
+	names := make([]string, getLength())
+	for i := range names {
+		names[i] = string(getBytes())
+	}
+	snap.Entities = make([]EntitySnapshot, getLength())
+	for i := range snap.Entities {
+		es := &snap.Entities[i]
+		es.ID = EntityID(getUvarint())
+		es.Components = make([]ComponentSnapshot, getLength())
+		for j := range es.Components {
+			nameIdx := getUvarint()
+			if readErr == nil && nameIdx >= uint64(len(names)) {
+				readErr = fmt.Errorf("%w: component name index %d out of range", ErrInvalidSnapshot, nameIdx)
+			}
+			data := getBytes()
+			if readErr != nil {
+				break
+			}
+			es.Components[j] = ComponentSnapshot{Type: names[nameIdx], Data: data}
+		}
+		if readErr != nil {
+			break
+		}
+	}
+	if n := getLength(); n > 0 {
+		snap.Parents = make([]ParentLink, n)
+		for i := range snap.Parents {
+			snap.Parents[i].Child = EntityID(getUvarint())
+			snap.Parents[i].Parent = EntityID(getUvarint())
+		}
+	}
+	if readErr != nil {
+		if errors.Is(readErr, io.EOF) {
+			readErr = io.ErrUnexpectedEOF
+		}
+		return nil, readErr
+	}
+	return snap, nil
+}
@@ -0,0 +1,169 @@
+package ecs
+
+import (
+	"bytes"
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func newTestRegistry() *Registry {
+	reg := NewRegistry()
+	reg.Register("position", func() Component { return &testPosition{} })
+	reg.Register("velocity", func() Component { return &testVelocity{} })
+	reg.Register("tag", func() Component { return &testTag{} })
+	return reg
+}
+
+func newSnapshotTestWorld() (*World, EntityID, EntityID) {
+	w := NewWorld()
+	tank := w.NewEntity()
+	w.AddComponent(tank, &testPosition{X: 1, Y: 2})
+	w.AddComponent(tank, &testVelocity{DX: 3})
+	dead := w.NewEntity()
+	w.DestroyEntity(dead)
+	crate := w.NewEntity()
+	w.AddComponent(crate, &testTag{})
+	turret := w.NewEntity()
+	w.AddComponent(turret, &testPosition{})
+	_ = w.SetParent(turret, tank)
+	return w, tank, crate
+}
+
+func TestWorld_SnapshotRestoreRewindsState(t *testing.T) {
+	reg := newTestRegistry()
+	w, tank, crate := newSnapshotTestWorld()
+
+	snap, err := w.Snapshot(reg)
+	if err != nil {
+		t.Fatalf("Snapshot failed: %v", err)
+	}
+
+	// Mutate the world after taking the snapshot.
+	p, _ := Get[*testPosition](w, tank)
+	p.X = 100
+	w.DestroyEntity(crate)
+	w.NewEntity()
+
+	if err := w.Restore(reg, snap); err != nil {
+		t.Fatalf("Restore failed: %v", err)
+	}
+
+	p, ok := Get[*testPosition](w, tank)
+	if !ok || p.X != 1 || p.Y != 2 {
+		t.Fatalf("restored tank position = %+v, %v; want {1 2}", p, ok)
+	}
+	if !w.IsAlive(crate) || !w.HasComponent(crate, testTypeTag) {
+		t.Fatalf("crate was not restored")
+	}
+	if children := w.Children(tank); len(children) != 1 {
+		t.Fatalf("tank has %d children after restore, want its turret", len(children))
+	}
+
+	// Slot recycling continues exactly as it would have in the original world.
+	original, _, _ := newSnapshotTestWorld()
+	if got, want := w.NewEntity(), original.NewEntity(); got != want {
+		t.Fatalf("NewEntity after restore = %v, want %v", got, want)
+	}
+}
+
+func TestSnapshot_JSONAndBinaryRoundTrip(t *testing.T) {
+	reg := newTestRegistry()
+	w, _, _ := newSnapshotTestWorld()
+	snap, err := w.Snapshot(reg)
+	if err != nil {
+		t.Fatalf("Snapshot failed: %v", err)
+	}
+
+	var jsonBuf, binBuf bytes.Buffer
+	if err := snap.WriteJSON(&jsonBuf); err != nil {
+		t.Fatalf("WriteJSON failed: %v", err)
+	}
+	if err := snap.WriteBinary(&binBuf); err != nil {
+		t.Fatalf("WriteBinary failed: %v", err)
+	}
+	if binBuf.Len() >= jsonBuf.Len() {
+		t.Errorf("binary snapshot (%d bytes) is not smaller than JSON (%d bytes)", binBuf.Len(), jsonBuf.Len())
+	}
+
+	fromJSON, err := ReadSnapshotJSON(&jsonBuf)
+	if err != nil {
+		t.Fatalf("ReadSnapshotJSON failed: %v", err)
+	}
+	fromBinary, err := ReadSnapshotBinary(&binBuf)
+	if err != nil {
+		t.Fatalf("ReadSnapshotBinary failed: %v", err)
+	}
+
+	for name, decoded := range map[string]*Snapshot{"json": fromJSON, "binary": fromBinary} {
+		restored := NewWorld()
+		if err := restored.Restore(reg, decoded); err != nil {
+			t.Fatalf("%s: Restore failed: %v", name, err)
+		}
+		again, err := restored.Snapshot(reg)
+		if err != nil {
+			t.Fatalf("%s: Snapshot of restored world failed: %v", name, err)
+		}
+		if !reflect.DeepEqual(again, snap) {
+			t.Fatalf("%s: restored snapshot differs from original\n got: %+v\nwant: %+v", name, again, snap)
+		}
+	}
+}
+
+func TestSnapshot_Errors(t *testing.T) {
+	w := NewWorld()
+	id := w.NewEntity()
+	w.AddComponent(id, &testPosition{})
+
+	if _, err := w.Snapshot(NewRegistry()); !errors.Is(err, ErrUnregisteredComponent) {
+		t.Fatalf("Snapshot with empty registry = %v, want ErrUnregisteredComponent", err)
+	}
+
+	bad := &Snapshot{Slots: []uint32{0, 1}, Entities: []EntitySnapshot{{ID: newEntityID(1, 2)}}}
+	if err := w.Restore(newTestRegistry(), bad); !errors.Is(err, ErrInvalidSnapshot) {
+		t.Fatalf("Restore with mismatched generation = %v, want ErrInvalidSnapshot", err)
+	}
+	if !w.HasComponent(id, testTypePosition) {
+		t.Fatalf("failed Restore modified the world")
+	}
+
+	if _, err := ReadSnapshotBinary(bytes.NewReader([]byte("nope!"))); !errors.Is(err, ErrInvalidSnapshot) {
+		t.Fatalf("ReadSnapshotBinary with bad header = %v, want ErrInvalidSnapshot", err)
+	}
+}
+
+func TestWorld_RestoreValidatesFreeList(t *testing.T) {
+	tests := []struct {
+		name string
+		free []uint32
+	}{
+		{"duplicate entry", []uint32{2, 2}},
+		{"dead slot missing", []uint32{}},
+		{"dead slot missing among others", []uint32{3}},
+	}
+	for _, tt := range tests {
+		w := NewWorld()
+		// Slot 1 is alive; slots 2 and 3 are dead.
+		snap := &Snapshot{
+			Slots:    []uint32{0, 1, 2, 2},
+			Free:     tt.free,
+			Entities: []EntitySnapshot{{ID: newEntityID(1, 1)}},
+		}
+		if err := w.Restore(newTestRegistry(), snap); !errors.Is(err, ErrInvalidSnapshot) {
+			t.Errorf("%s: Restore = %v, want ErrInvalidSnapshot", tt.name, err)
+		}
+	}
+
+	w := NewWorld()
+	snap := &Snapshot{
+		Slots:    []uint32{0, 1, 2, 2},
+		Free:     []uint32{3, 2},
+		Entities: []EntitySnapshot{{ID: newEntityID(1, 1)}},
+	}
+	if err := w.Restore(newTestRegistry(), snap); err != nil {
+		t.Fatalf("Restore with a complete free list: %v", err)
+	}
+	if a, b := w.NewEntity(), w.NewEntity(); a == b || a.Index() == 1 || b.Index() == 1 {
+		t.Fatalf("NewEntity after Restore returned %v and %v", a, b)
+	}
+}
@@ -0,0 +1,147 @@
+package ecs
+
+// componentStore is a sparse set holding all components of a single
+// ComponentType.
+//
+// Components are packed densely in insertion order so that iterating a
+// component type touches a contiguous slice instead of walking a map. The
+// sparse slice maps an entity's slot index to its position in the dense
+// slices; the full EntityID kept in dense guards against stale handles.
+//
+// Alongside every component the store keeps the world tick at which it was
+// added and last changed, plus a log of recent removals, for change
+// detection.
+type componentStore struct {
+	// sparse maps slot index to dense index + 1; zero marks an absent entity.
+	sparse  []int
+	dense   []EntityID
+	data    []Component
+	added   []uint64
+	changed []uint64
+	removed []removal
+}
+
+// maxRemovalLog bounds the removal log of a store. Worlds whose tick is never
+// advanced would otherwise log every removal forever; once the bound is hit
+// the older half of the log is dropped.
+const maxRemovalLog = 1 << 15
+
+// removal records that an entity lost its component at a given tick.
+type removal struct {
+	id   EntityID
+	tick uint64
+}
+
+func newComponentStore() *componentStore {
+	return &componentStore{}
+}
+
+// len returns the number of components in the store.
+func (s *componentStore) len() int {
+	return len(s.dense)
+}
+
+// index returns the dense index for an entity, or -1 if absent.
+func (s *componentStore) index(id EntityID) int {
+	slot := int(id.Index())
+	if slot >= len(s.sparse) {
+		return -1
+	}
+	i := s.sparse[slot] - 1
+	if i < 0 || s.dense[i] != id {
+		return -1
+	}
+	return i
+}
+
+// get returns the component stored for an entity, if any.
+func (s *componentStore) get(id EntityID) (Component, bool) {
+	i := s.index(id)
+	if i < 0 {
+		return nil, false
+	}
+	return s.data[i], true
+}
+
+// set inserts or replaces the component stored for an entity. Inserting
+// stamps both the added and changed ticks; replacing counts as a change.
+func (s *componentStore) set(id EntityID, c Component, tick uint64) {
+	if i := s.index(id); i >= 0 {
+		s.data[i] = c
+		s.changed[i] = tick
+		return
+	}
+	slot := int(id.Index())
+	if n := slot + 1; n > len(s.sparse) {
+		s.sparse = append(s.sparse, make([]int, n-len(s.sparse))...)
+	}
+	s.dense = append(s.dense, id)
+	s.data = append(s.data, c)
+	s.added = append(s.added, tick)
+	s.changed = append(s.changed, tick)
+	s.sparse[slot] = len(s.dense)
+}
+
+// markChanged stamps the changed tick of an entity's component. It reports
+// whether the entity has a component in this store.
+func (s *componentStore) markChanged(id EntityID, tick uint64) bool {
+	i := s.index(id)
+	if i < 0 {
+		return false
+	}
+	s.changed[i] = tick
+	return true
+}
+
+// remove deletes the component stored for an entity by swapping the last
+// dense element into its slot and logs the removal. It reports whether a
+// component was removed.
+func (s *componentStore) remove(id EntityID, tick uint64) bool {
+	i := s.index(id)
+	if i < 0 {
+		return false
+	}
+	last := len(s.dense) - 1
+	if i != last {
+		moved := s.dense[last]
+		s.dense[i] = moved
+		s.data[i] = s.data[last]
+		s.added[i] = s.added[last]
+		s.changed[i] = s.changed[last]
+		s.sparse[moved.Index()] = i + 1
+	}
+	s.data[last] = nil
+	s.dense = s.dense[:last]
+	s.data = s.data[:last]
+	s.added = s.added[:last]
+	s.changed = s.changed[:last]
+	s.sparse[id.Index()] = 0
+	s.logRemoval(id, tick)
+	return true
+}
+
+// logRemoval appends to the removal log, first dropping records older than
+// the previous tick and keeping the log within maxRemovalLog records.
+func (s *componentStore) logRemoval(id EntityID, tick uint64) {
+	if len(s.removed) > 0 && s.removed[0].tick+1 < tick {
+		s.pruneRemovals(tick - 1)
+	}
+	s.removed = append(s.removed, removal{id: id, tick: tick})
+	if len(s.removed) > maxRemovalLog {
+		kept := copy(s.removed, s.removed[len(s.removed)-maxRemovalLog/2:])
+		clear(s.removed[kept:])
+		s.removed = s.removed[:kept]
+	}
+}
+
+// pruneRemovals drops removal records older than tick.
+func (s *componentStore) pruneRemovals(tick uint64) {
+	kept := s.removed[:0]
+	for _, r := range s.removed {
+		if r.tick >= tick {
+			kept = append(kept, r)
+		}
+	}
+	clear(s.removed[len(kept):])
+	s.removed = kept
+}
@@ -1,12 +1,41 @@
 package ecs
 
+import (
+	"cmp"
+	"slices"
+)
+
 // EntityID is an opaque handle to an entity in the World.
-type EntityID int
+//
+// The low 32 bits hold the index of the entity's slot and the high 32 bits
+// hold the slot's generation. Slots are recycled after DestroyEntity, and
+// bumping the generation makes handles to the destroyed entity stale instead
+// of letting them alias the slot's next occupant. The zero EntityID never
+// refers to a live entity.
+type EntityID uint64
+
+const entityIndexBits = 32
+
+// newEntityID packs a slot index and generation into an EntityID.
+func newEntityID(index, generation uint32) EntityID {
+	return EntityID(uint64(generation)<<entityIndexBits | uint64(index))
+}
+
+// Index returns the slot index encoded in the ID.
+func (id EntityID) Index() uint32 {
+	return uint32(id)
+}
+
+// Generation returns the slot generation encoded in the ID.
+func (id EntityID) Generation() uint32 {
+	return uint32(id >> entityIndexBits)
+}
 
 // ComponentType identifies a particular component kind.
 //
-// It is used both as a key into component storage and to compute
-// bitmasks for fast "has components" queries.
+// It is used both as a key into component storage and as a bit position in
+// an entity's Mask. Values should be small, dense, non-negative integers;
+// there is no upper bound on how many types may be used.
 type ComponentType int
 
 // Component is the interface implemented by all components stored in the
@@ -16,133 +45,262 @@ type Component interface {
 	Type() ComponentType
 }
 
-// Entity holds metadata about a single entity.
+// Entity holds metadata about a single entity slot.
 type Entity struct {
-	id   EntityID
-	mask uint64
+	generation uint32
+	mask       Mask
+	alive      bool
 }
 
 // World owns entities and generic component storage.
+//
+// Entities live in a slice of slots indexed by EntityID.Index and every
+// component type is kept in its own sparse set (see componentStore), so
+// lookups are plain slice accesses and queries iterate densely packed
+// components.
 type World struct {
-	// nextID is incremented for every new entity.
-	nextID EntityID
+	entities []Entity
+	// free holds the indices of destroyed slots available for reuse.
+	free   []uint32
+	stores []*componentStore
+
+	commands  *CommandBuffer
+	events    *eventBus
+	observers *observers
+	resources *resources
+	hierarchy *hierarchy
 
-	entities   map[EntityID]*Entity
-	components map[ComponentType]map[EntityID]Component
+	// tick is the current change-detection tick (see AdvanceTick).
+	tick uint64
+	// staged is non-nil only for system views in a parallel batch.
+	staged *stagedEvents
+	// viewOf names the system a view belongs to; it is empty for real worlds.
+	viewOf string
 }
 
 // NewWorld constructs an empty World.
 func NewWorld() *World {
 	return &World{
-		nextID:     1,
-		entities:   make(map[EntityID]*Entity),
-		components: make(map[ComponentType]map[EntityID]Component),
+		// Slot 0 is never handed out so that the zero EntityID stays invalid.
+		entities:  make([]Entity, 1),
+		commands:  NewCommandBuffer(),
+		events:    newEventBus(),
+		observers: newObservers(),
+		resources: newResources(),
+		hierarchy: newHierarchy(),
+		tick:      1,
 	}
 }
 
-// NewEntity creates a new entity and returns its ID.
+// NewEntity creates a new entity and returns its ID. Slots of destroyed
+// entities are reused with a bumped generation.
 func (w *World) NewEntity() EntityID {
-	id := w.nextID
-	w.nextID++
-	w.entities[id] = &Entity{id: id, mask: 0}
-	return id
+	w.checkStructural("NewEntity")
+	var index uint32
+	if n := len(w.free); n > 0 {
+		index = w.free[n-1]
+		w.free = w.free[:n-1]
+	} else {
+		index = uint32(len(w.entities))
+		w.entities = append(w.entities, Entity{generation: 1})
+	}
+	e := &w.entities[index]
+	e.alive = true
+	return newEntityID(index, e.generation)
 }
 
-// DestroyEntity removes the entity and all its components.
+// DestroyEntity removes the entity, all its components and, recursively, all
+// of its children. Destroying a stale or unknown ID is a no-op. Children are
+// destroyed before their parent, and OnRemove hooks run for every component
+// while its entity is still intact.
 func (w *World) DestroyEntity(id EntityID) {
-	delete(w.entities, id)
-	for t, store := range w.components {
-		delete(store, id)
-		w.components[t] = store
+	w.checkStructural("DestroyEntity")
+	if w.entity(id) == nil {
+		return
 	}
+	for _, child := range w.Children(id) {
+		w.DestroyEntity(child)
+	}
+	w.RemoveParent(id)
+
+	e := w.entity(id)
+	if e == nil {
+		return
+	}
+	for _, t := range e.mask.Types() {
+		if c, ok := w.GetComponent(id, t); ok {
+			w.observers.notifyRemove(w, id, c)
+		}
+	}
+	// Hooks may have changed the world; look the entity up again.
+	if e = w.entity(id); e == nil {
+		return
+	}
+	for _, t := range e.mask.Types() {
+		if store := w.store(t); store != nil {
+			store.remove(id, w.tick)
+		}
+	}
+	next := e.generation + 1
+	if next == 0 {
+		// Skip generation zero on wrap-around so IDs never become zero.
+		next = 1
+	}
+	*e = Entity{generation: next}
+	w.free = append(w.free, id.Index())
 }
 
-// bitFor returns the bitmask corresponding to a component type.
-func bitFor(t ComponentType) uint64 {
-	return 1 << uint(t)
+// IsAlive reports whether id refers to an entity that has not been destroyed.
+func (w *World) IsAlive(id EntityID) bool {
+	return w.entity(id) != nil
 }
 
-// ensureEntity makes sure the entity metadata exists.
-func (w *World) ensureEntity(id EntityID) *Entity {
-	if e, ok := w.entities[id]; ok {
-		return e
+// Entities returns all live entities ordered by slot index, for tools such
+// as debug inspectors that need to list the whole world.
+func (w *World) Entities() []EntityID {
+	result := make([]EntityID, 0, len(w.entities)-len(w.free))
+	for index, e := range w.entities {
+		if e.alive {
+			result = append(result, newEntityID(uint32(index), e.generation))
+		}
+	}
+	return result
+}
+
+// entity returns the metadata for a live entity, or nil if id is unknown or
+// stale.
+func (w *World) entity(id EntityID) *Entity {
+	index := id.Index()
+	if index == 0 || int(index) >= len(w.entities) {
+		return nil
+	}
+	e := &w.entities[index]
+	if !e.alive || e.generation != id.Generation() {
+		return nil
 	}
-	e := &Entity{id: id, mask: 0}
-	w.entities[id] = e
 	return e
 }
 
-// AddComponent attaches a component to an entity.
+// store returns the component store for a type, or nil if none exists yet.
+func (w *World) store(t ComponentType) *componentStore {
+	if t < 0 || int(t) >= len(w.stores) {
+		return nil
+	}
+	return w.stores[t]
+}
+
+// AddComponent attaches a component to an entity, replacing any existing
+// component of the same type. Adding to a stale or unknown ID is a no-op, so
+// a destroyed entity can never be resurrected through an old handle.
+// Components with a negative type are ignored, as they are by Mask.
+//
+// OnAdd hooks run after the component is attached. Replacing a component
+// first runs the OnRemove hooks for the old value.
 func (w *World) AddComponent(id EntityID, c Component) {
-	e := w.ensureEntity(id)
+	w.checkStructural("AddComponent")
 	t := c.Type()
-	store, ok := w.components[t]
-	if !ok {
-		store = make(map[EntityID]Component)
-		w.components[t] = store
+	if t < 0 || w.entity(id) == nil {
+		return
+	}
+	if old, ok := w.GetComponent(id, t); ok {
+		w.observers.notifyRemove(w, id, old)
+	}
+	// Hooks may have changed the world; look the entity up again.
+	e := w.entity(id)
+	if e == nil {
+		return
 	}
-	store[id] = c
-	e.mask |= bitFor(t)
+	for int(t) >= len(w.stores) {
+		w.stores = append(w.stores, nil)
+	}
+	store := w.stores[t]
+	if store == nil {
+		store = newComponentStore()
+		w.stores[t] = store
+	}
+	store.set(id, c, w.tick)
+	e.mask.Set(t)
+	w.observers.notifyAdd(w, id, c)
 }
 
 // RemoveComponent detaches a component of the given type from an entity.
+// OnRemove hooks run before the component is detached.
 func (w *World) RemoveComponent(id EntityID, t ComponentType) {
-	if store, ok := w.components[t]; ok {
-		delete(store, id)
+	w.checkStructural("RemoveComponent")
+	if old, ok := w.GetComponent(id, t); ok {
+		w.observers.notifyRemove(w, id, old)
+	}
+	if store := w.store(t); store != nil {
+		store.remove(id, w.tick)
 	}
-	if e, ok := w.entities[id]; ok {
-		e.mask &^= bitFor(t)
+	if e := w.entity(id); e != nil {
+		e.mask.Clear(t)
 	}
 }
 
 // GetComponent returns the component of the given type for an entity, if any.
 func (w *World) GetComponent(id EntityID, t ComponentType) (Component, bool) {
-	store, ok := w.components[t]
-	if !ok {
+	store := w.store(t)
+	if store == nil {
 		return nil, false
 	}
-	c, ok := store[id]
-	return c, ok
+	return store.get(id)
 }
 
 // HasComponent reports whether the entity has a component of the given type.
 func (w *World) HasComponent(id EntityID, t ComponentType) bool {
-	e, ok := w.entities[id]
-	if !ok {
+	e := w.entity(id)
+	if e == nil {
 		return false
 	}
-	return e.mask&bitFor(t) != 0
+	return e.mask.Has(t)
 }
 
-// Mask returns the current component mask for an entity.
-func (w *World) Mask(id EntityID) (uint64, bool) {
-	e, ok := w.entities[id]
-	if !ok {
-		return 0, false
+// Mask returns a copy of the current component mask for an entity.
+func (w *World) Mask(id EntityID) (Mask, bool) {
+	e := w.entity(id)
+	if e == nil {
+		return Mask{}, false
 	}
-	return e.mask, true
+	return e.mask.Clone(), true
 }
 
-// MaskFor computes a mask for the given component types.
-func MaskFor(types ...ComponentType) uint64 {
-	var m uint64
-	for _, t := range types {
-		m |= bitFor(t)
+// Find returns all entities whose component mask contains every type in required.
+//
+// Only the smallest component store named by required is scanned; every
+// candidate is then checked against the full mask. Results are ordered by
+// slot index, so the order is stable and identical across runs regardless of
+// the order in which components were added or removed.
+func (w *World) Find(required Mask) []EntityID {
+	if required.IsEmpty() {
+		return nil
 	}
-	return m
-}
 
-// Find returns all entities whose component mask contains all bits in required.
-func (w *World) Find(required uint64) []EntityID {
-	if required == 0 {
-		return nil
+	var smallest *componentStore
+	for _, t := range required.Types() {
+		store := w.store(t)
+		if store == nil || store.len() == 0 {
+			return make([]EntityID, 0)
+		}
+		if smallest == nil || store.len() < smallest.len() {
+			smallest = store
+		}
 	}
 
-	result := make([]EntityID, 0)
-	for id, e := range w.entities {
-		if e.mask&required == required {
+	result := make([]EntityID, 0, smallest.len())
+	for _, id := range smallest.dense {
+		if w.entities[id.Index()].mask.Contains(required) {
 			result = append(result, id)
 		}
 	}
+	sortBySlot(result)
 	return result
 }
+
+// sortBySlot orders live entity IDs by slot index. Live entities never share
+// a slot, so this is a total order.
+func sortBySlot(ids []EntityID) {
+	slices.SortFunc(ids, func(a, b EntityID) int {
+		return cmp.Compare(a.Index(), b.Index())
+	})
+}
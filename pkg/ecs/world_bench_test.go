@@ -0,0 +1,131 @@
+package ecs
+
+import "testing"
+
+// legacyWorld is the original map-of-maps World implementation, kept here
+// only as a baseline for the storage benchmarks below.
+type legacyWorld struct {
+	nextID     EntityID
+	entities   map[EntityID]uint64
+	components map[ComponentType]map[EntityID]Component
+}
+
+func newLegacyWorld() *legacyWorld {
+	return &legacyWorld{
+		nextID:     1,
+		entities:   make(map[EntityID]uint64),
+		components: make(map[ComponentType]map[EntityID]Component),
+	}
+}
+
+func (w *legacyWorld) NewEntity() EntityID {
+	id := w.nextID
+	w.nextID++
+	w.entities[id] = 0
+	return id
+}
+
+func (w *legacyWorld) AddComponent(id EntityID, c Component) {
+	t := c.Type()
+	store, ok := w.components[t]
+	if !ok {
+		store = make(map[EntityID]Component)
+		w.components[t] = store
+	}
+	store[id] = c
+	w.entities[id] |= 1 << uint(t)
+}
+
+func (w *legacyWorld) DestroyEntity(id EntityID) {
+	delete(w.entities, id)
+	for _, store := range w.components {
+		delete(store, id)
+	}
+}
+
+func (w *legacyWorld) GetComponent(id EntityID, t ComponentType) (Component, bool) {
+	store, ok := w.components[t]
+	if !ok {
+		return nil, false
+	}
+	c, ok := store[id]
+	return c, ok
+}
+
+func (w *legacyWorld) Find(requiredTypes Mask) []EntityID {
+	var required uint64
+	for _, t := range requiredTypes.Types() {
+		required |= 1 << uint(t)
+	}
+	result := make([]EntityID, 0)
+	for id, mask := range w.entities {
+		if mask&required == required {
+			result = append(result, id)
+		}
+	}
+	return result
+}
+
+// benchWorld is the subset of the World API exercised by the benchmarks.
+type benchWorld interface {
+	NewEntity() EntityID
+	AddComponent(EntityID, Component)
+	DestroyEntity(EntityID)
+	GetComponent(EntityID, ComponentType) (Component, bool)
+	Find(Mask) []EntityID
+}
+
+// populate creates n entities with a position; every fourth entity also
+// moves, mirroring a world with many static props and fewer projectiles.
+func populate(w benchWorld, n int) []EntityID {
+	ids := make([]EntityID, n)
+	for i := range ids {
+		id := w.NewEntity()
+		w.AddComponent(id, &testPosition{})
+		if i%4 == 0 {
+			w.AddComponent(id, &testVelocity{DX: 1, DY: 1})
+		}
+		ids[i] = id
+	}
+	return ids
+}
+
+func benchmarkMoveSystem(b *testing.B, w benchWorld) {
+	populate(w, 2000)
+	required := MaskFor(testTypePosition, testTypeVelocity)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		for _, id := range w.Find(required) {
+			cp, _ := w.GetComponent(id, testTypePosition)
+			cv, _ := w.GetComponent(id, testTypeVelocity)
+			p := cp.(*testPosition)
+			v := cv.(*testVelocity)
+			p.X += v.DX
+			p.Y += v.DY
+		}
+	}
+}
+
+func BenchmarkMoveSystem_SparseSet(b *testing.B) { benchmarkMoveSystem(b, NewWorld()) }
+func BenchmarkMoveSystem_Legacy(b *testing.B)    { benchmarkMoveSystem(b, newLegacyWorld()) }
+
+func benchmarkSpawnDestroy(b *testing.B, w benchWorld) {
+	populate(w, 2000)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		// One frame of projectile churn.
+		spawned := make([]EntityID, 0, 50)
+		for j := 0; j < 50; j++ {
+			id := w.NewEntity()
+			w.AddComponent(id, &testPosition{})
+			w.AddComponent(id, &testVelocity{})
+			spawned = append(spawned, id)
+		}
+		for _, id := range spawned {
+			w.DestroyEntity(id)
+		}
+	}
+}
+
+func BenchmarkSpawnDestroy_SparseSet(b *testing.B) { benchmarkSpawnDestroy(b, NewWorld()) }
+func BenchmarkSpawnDestroy_Legacy(b *testing.B)    { benchmarkSpawnDestroy(b, newLegacyWorld()) }
@@ -0,0 +1,180 @@
+package ecs
+
+import (
+	"sort"
+	"testing"
+)
+
+func TestWorld_AddGetRemoveComponent(t *testing.T) {
+	w := NewWorld()
+	id := w.NewEntity()
+	w.AddComponent(id, &testPosition{X: 1})
+
+	if !w.HasComponent(id, testTypePosition) {
+		t.Fatalf("HasComponent = false after AddComponent")
+	}
+	c, ok := w.GetComponent(id, testTypePosition)
+	if !ok || c.(*testPosition).X != 1 {
+		t.Fatalf("GetComponent = %v, %v; want position with X=1", c, ok)
+	}
+
+	w.RemoveComponent(id, testTypePosition)
+	if w.HasComponent(id, testTypePosition) {
+		t.Fatalf("HasComponent = true after RemoveComponent")
+	}
+	if _, ok := w.GetComponent(id, testTypePosition); ok {
+		t.Fatalf("GetComponent ok = true after RemoveComponent")
+	}
+}
+
+func TestWorld_DestroyEntityRemovesAllComponents(t *testing.T) {
+	w := NewWorld()
+	a := w.NewEntity()
+	b := w.NewEntity()
+	w.AddComponent(a, &testPosition{})
+	w.AddComponent(a, &testVelocity{})
+	w.AddComponent(b, &testPosition{X: 7})
+
+	w.DestroyEntity(a)
+
+	if _, ok := w.Mask(a); ok {
+		t.Fatalf("Mask ok = true for destroyed entity")
+	}
+	if _, ok := w.GetComponent(a, testTypePosition); ok {
+		t.Fatalf("destroyed entity still has a position")
+	}
+	c, ok := w.GetComponent(b, testTypePosition)
+	if !ok || c.(*testPosition).X != 7 {
+		t.Fatalf("surviving entity lost its position after neighbour was destroyed")
+	}
+}
+
+func TestWorld_FindMatchesRequiredMask(t *testing.T) {
+	w := NewWorld()
+	both := w.NewEntity()
+	w.AddComponent(both, &testPosition{})
+	w.AddComponent(both, &testVelocity{})
+	onlyPos := w.NewEntity()
+	w.AddComponent(onlyPos, &testPosition{})
+	alsoBoth := w.NewEntity()
+	w.AddComponent(alsoBoth, &testVelocity{})
+	w.AddComponent(alsoBoth, &testPosition{})
+
+	got := w.Find(MaskFor(testTypePosition, testTypeVelocity))
+	sort.Slice(got, func(i, j int) bool { return got[i] < got[j] })
+	if len(got) != 2 || got[0] != both || got[1] != alsoBoth {
+		t.Fatalf("Find = %v, want [%v %v]", got, both, alsoBoth)
+	}
+
+	if got := w.Find(MaskFor(testTypeTag)); len(got) != 0 {
+		t.Fatalf("Find for unused type = %v, want empty", got)
+	}
+	if got := w.Find(Mask{}); got != nil {
+		t.Fatalf("Find(empty mask) = %v, want nil", got)
+	}
+}
+
+func TestWorld_DestroyedSlotIsRecycledWithNewGeneration(t *testing.T) {
+	w := NewWorld()
+	old := w.NewEntity()
+	w.AddComponent(old, &testPosition{X: 1})
+	w.DestroyEntity(old)
+
+	if w.IsAlive(old) {
+		t.Fatalf("IsAlive = true for destroyed entity")
+	}
+
+	reused := w.NewEntity()
+	if reused.Index() != old.Index() {
+		t.Fatalf("expected slot %d to be recycled, got slot %d", old.Index(), reused.Index())
+	}
+	if reused.Generation() == old.Generation() || reused == old {
+		t.Fatalf("recycled entity kept generation %d", old.Generation())
+	}
+	if !w.IsAlive(reused) {
+		t.Fatalf("IsAlive = false for recycled entity")
+	}
+	if w.HasComponent(reused, testTypePosition) {
+		t.Fatalf("recycled entity inherited components of the destroyed entity")
+	}
+}
+
+func TestWorld_StaleHandleDoesNotAliasNewEntity(t *testing.T) {
+	w := NewWorld()
+	stale := w.NewEntity()
+	w.DestroyEntity(stale)
+	fresh := w.NewEntity()
+	w.AddComponent(fresh, &testPosition{X: 3})
+
+	// Operations through the stale handle must neither resurrect the old
+	// entity nor touch the new occupant of the slot.
+	w.AddComponent(stale, &testVelocity{})
+	w.RemoveComponent(stale, testTypePosition)
+	w.DestroyEntity(stale)
+
+	if w.IsAlive(stale) {
+		t.Fatalf("stale handle was resurrected by AddComponent")
+	}
+	if _, ok := w.GetComponent(stale, testTypePosition); ok {
+		t.Fatalf("GetComponent through stale handle returned the new entity's component")
+	}
+	if !w.IsAlive(fresh) {
+		t.Fatalf("DestroyEntity through stale handle destroyed the new entity")
+	}
+	c, ok := w.GetComponent(fresh, testTypePosition)
+	if !ok || c.(*testPosition).X != 3 {
+		t.Fatalf("new entity lost its position through the stale handle")
+	}
+	if w.HasComponent(fresh, testTypeVelocity) {
+		t.Fatalf("AddComponent through stale handle attached to the new entity")
+	}
+}
+
+func TestWorld_ZeroIDIsNeverAlive(t *testing.T) {
+	w := NewWorld()
+	w.NewEntity()
+	if w.IsAlive(0) {
+		t.Fatalf("IsAlive(0) = true")
+	}
+}
+
+func TestWorld_EntitiesListsLiveEntitiesInSlotOrder(t *testing.T) {
+	w := NewWorld()
+	a := w.NewEntity()
+	b := w.NewEntity()
+	c := w.NewEntity()
+	w.DestroyEntity(b)
+	d := w.NewEntity() // reuses b's slot
+
+	got := w.Entities()
+	want := []EntityID{a, d, c}
+	if len(got) != len(want) {
+		t.Fatalf("Entities() = %v, want %v", got, want)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Fatalf("Entities() = %v, want %v", got, want)
+		}
+	}
+}
+
+type negativeComponent struct{}
+
+func (negativeComponent) Type() ComponentType { return -1 }
+
+func TestWorld_NegativeComponentTypesAreIgnored(t *testing.T) {
+	w := NewWorld()
+	id := w.NewEntity()
+
+	w.AddComponent(id, negativeComponent{})
+	if w.HasComponent(id, -1) {
+		t.Fatalf("HasComponent(-1) = true after adding a negative type")
+	}
+	if _, ok := w.GetComponent(id, -1); ok {
+		t.Fatalf("GetComponent(-1) found a component")
+	}
+	w.RemoveComponent(id, -1)
+	if mask, _ := w.Mask(id); len(mask.Types()) != 0 {
+		t.Fatalf("mask = %v, want empty", mask.Types())
+	}
+}
@@ -0,0 +1,408 @@
+package input
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"maps"
+	"os"
+	"path/filepath"
+	"slices"
+
+	"github.com/hajimehoshi/ebiten/v2"
+)
+
+// DefaultProfile is the name of the built-in WASD binding profile.
+const DefaultProfile = "default"
+
+// actionGroups lists actions that are read at the same time. A key may be
+// shared by actions of different groups (W moves the tank and navigates
+// menus) but binding it twice within one group is a conflict.
+var actionGroups = [][]Action{
+	{ActionMoveForward, ActionMoveBackward, ActionTurnLeft, ActionTurnRight, ActionFire, ActionPause, ActionDebugToggle},
+	{ActionPause, ActionMenuUp, ActionMenuDown, ActionMenuConfirm},
+}
+
+// Actions returns every action that can be bound, gameplay actions first.
+func Actions() []Action {
+	var actions []Action
+	for _, group := range actionGroups {
+		for _, a := range group {
+			if !slices.Contains(actions, a) {
+				actions = append(actions, a)
+			}
+		}
+	}
+	return actions
+}
+
+// Bindings maps actions to the keys, mouse buttons, gamepad buttons and
+// gamepad stick directions that trigger them. It encodes to JSON as an object
+// such as {"keys": {"fire": ["Space"]}, "mouse": {"fire": ["mouse_left"]},
+// "buttons": {"fire": ["right_bottom"]}, "axes": {"turn_left": ["left_x-"]},
+// "analog": {...}, "mouse_aim": false}.
+type Bindings struct {
+	keys    map[Action][]ebiten.Key
+	mouse   map[Action][]MouseButton
+	buttons map[Action][]GamepadButton
+	axes    map[Action][]GamepadAxis
+	analog  AnalogConfig
+	// mouseAim selects the control scheme in which the tank turns towards
+	// the mouse cursor instead of using the turn actions.
+	mouseAim bool
+}
+
+// DefaultBindings returns the built-in WASD, mouse and standard gamepad
+// bindings: the left mouse button fires, the left stick or d-pad steers, the
+// triggers drive and A / Cross fires. F1 toggles the debug inspector. Mouse
+// aim is off.
+func DefaultBindings() *Bindings {
+	return &Bindings{
+		keys: map[Action][]ebiten.Key{
+			ActionMoveForward:  {ebiten.KeyW},
+			ActionMoveBackward: {ebiten.KeyS},
+			ActionTurnLeft:     {ebiten.KeyA},
+			ActionTurnRight:    {ebiten.KeyD},
+			ActionFire:         {ebiten.KeySpace},
+			ActionPause:        {ebiten.KeyEscape},
+			ActionMenuUp:       {ebiten.KeyArrowUp, ebiten.KeyW},
+			ActionMenuDown:     {ebiten.KeyArrowDown, ebiten.KeyS},
+			ActionMenuConfirm:  {ebiten.KeyEnter, ebiten.KeySpace},
+			ActionDebugToggle:  {ebiten.KeyF1},
+		},
+		mouse: map[Action][]MouseButton{
+			ActionFire: {MouseButton(ebiten.MouseButtonLeft)},
+		},
+		buttons: map[Action][]GamepadButton{
+			ActionMoveForward:  {button(ebiten.StandardGamepadButtonFrontBottomRight)},
+			ActionMoveBackward: {button(ebiten.StandardGamepadButtonFrontBottomLeft)},
+			ActionTurnLeft:     {button(ebiten.StandardGamepadButtonLeftLeft)},
+			ActionTurnRight:    {button(ebiten.StandardGamepadButtonLeftRight)},
+			ActionFire:         {button(ebiten.StandardGamepadButtonRightBottom), button(ebiten.StandardGamepadButtonFrontTopRight)},
+			ActionPause:        {button(ebiten.StandardGamepadButtonCenterRight)},
+			ActionMenuUp:       {button(ebiten.StandardGamepadButtonLeftTop)},
+			ActionMenuDown:     {button(ebiten.StandardGamepadButtonLeftBottom)},
+			ActionMenuConfirm:  {button(ebiten.StandardGamepadButtonRightBottom)},
+		},
+		axes: map[Action][]GamepadAxis{
+			ActionMoveForward:  {{Axis: ebiten.StandardGamepadAxisLeftStickVertical}},
+			ActionMoveBackward: {{Axis: ebiten.StandardGamepadAxisLeftStickVertical, Positive: true}},
+			ActionTurnLeft:     {{Axis: ebiten.StandardGamepadAxisLeftStickHorizontal}},
+			ActionTurnRight:    {{Axis: ebiten.StandardGamepadAxisLeftStickHorizontal, Positive: true}},
+			ActionMenuUp:       {{Axis: ebiten.StandardGamepadAxisLeftStickVertical}},
+			ActionMenuDown:     {{Axis: ebiten.StandardGamepadAxisLeftStickVertical, Positive: true}},
+		},
+		analog: DefaultAnalog,
+	}
+}
+
+func button(b ebiten.StandardGamepadButton) GamepadButton {
+	return GamepadButton(b)
+}
+
+// Keys returns the keys bound to an action.
+func (b *Bindings) Keys(a Action) []ebiten.Key {
+	return slices.Clone(b.keys[a])
+}
+
+// MouseButtons returns the mouse buttons bound to an action.
+func (b *Bindings) MouseButtons(a Action) []MouseButton {
+	return slices.Clone(b.mouse[a])
+}
+
+// Buttons returns the gamepad buttons bound to an action.
+func (b *Bindings) Buttons(a Action) []GamepadButton {
+	return slices.Clone(b.buttons[a])
+}
+
+// Axes returns the gamepad stick directions bound to an action.
+func (b *Bindings) Axes(a Action) []GamepadAxis {
+	return slices.Clone(b.axes[a])
+}
+
+// Analog returns the deadzone and response curve applied to gamepad values.
+func (b *Bindings) Analog() AnalogConfig {
+	return b.analog
+}
+
+// SetAnalog replaces the deadzone and response curve.
+func (b *Bindings) SetAnalog(c AnalogConfig) {
+	b.analog = c
+}
+
+// MouseAim reports whether the tank turns towards the mouse cursor.
+func (b *Bindings) MouseAim() bool {
+	return b.mouseAim
+}
+
+// SetMouseAim switches the mouse aim control scheme on or off.
+func (b *Bindings) SetMouseAim(on bool) {
+	b.mouseAim = on
+}
+
+// DescribeGamepad formats the gamepad inputs bound to an action for display,
+// for example "right_bottom, left_y-".
+func (b *Bindings) DescribeGamepad(a Action) string {
+	return describeGamepad(b.buttons[a], b.axes[a])
+}
+
+// Bind replaces the keys of an action and returns the conflicts the action
+// is now involved in. The binding is applied even if it conflicts, so a
+// settings screen can show the problem and let the player resolve it.
+func (b *Bindings) Bind(a Action, keys ...ebiten.Key) []Conflict {
+	b.keys[a] = slices.Clone(keys)
+	var conflicts []Conflict
+	for _, c := range b.Conflicts() {
+		if slices.Contains(c.Actions, a) {
+			conflicts = append(conflicts, c)
+		}
+	}
+	return conflicts
+}
+
+// BindMouse replaces the mouse buttons of an action.
+func (b *Bindings) BindMouse(a Action, buttons ...MouseButton) {
+	b.mouse[a] = slices.Clone(buttons)
+}
+
+// BindButtons replaces the gamepad buttons of an action.
+func (b *Bindings) BindButtons(a Action, buttons ...GamepadButton) {
+	b.buttons[a] = slices.Clone(buttons)
+}
+
+// BindAxes replaces the gamepad stick directions of an action.
+func (b *Bindings) BindAxes(a Action, axes ...GamepadAxis) {
+	b.axes[a] = slices.Clone(axes)
+}
+
+// Clone returns an independent copy of the bindings.
+func (b *Bindings) Clone() *Bindings {
+	return &Bindings{
+		keys:     cloneInputs(b.keys),
+		mouse:    cloneInputs(b.mouse),
+		buttons:  cloneInputs(b.buttons),
+		axes:     cloneInputs(b.axes),
+		analog:   b.analog,
+		mouseAim: b.mouseAim,
+	}
+}
+
+func cloneInputs[T any](m map[Action][]T) map[Action][]T {
+	c := make(map[Action][]T, len(m))
+	for a, inputs := range m {
+		c[a] = slices.Clone(inputs)
+	}
+	return c
+}
+
+// Conflict reports a key bound to several actions of the same group.
+// Gamepad bindings are not checked: a stick direction deliberately drives
+// both a movement and a menu action.
+type Conflict struct {
+	Key     ebiten.Key
+	Actions []Action
+}
+
+func (c Conflict) String() string {
+	return fmt.Sprintf("%s is bound to %v", c.Key, c.Actions)
+}
+
+// Conflicts returns every key bound to more than one action that is read at
+// the same time, ordered by group and key.
+func (b *Bindings) Conflicts() []Conflict {
+	var conflicts []Conflict
+	for _, group := range actionGroups {
+		byKey := make(map[ebiten.Key][]Action)
+		for _, a := range group {
+			for _, k := range b.keys[a] {
+				if !slices.Contains(byKey[k], a) {
+					byKey[k] = append(byKey[k], a)
+				}
+			}
+		}
+		var keys []ebiten.Key
+		for k, actions := range byKey {
+			if len(actions) > 1 {
+				keys = append(keys, k)
+			}
+		}
+		slices.Sort(keys)
+		for _, k := range keys {
+			conflicts = append(conflicts, Conflict{Key: k, Actions: byKey[k]})
+		}
+	}
+	return conflicts
+}
+
+// bindingsJSON is the encoded form of Bindings.
+type bindingsJSON struct {
+	Keys     map[Action][]ebiten.Key    `json:"keys"`
+	Mouse    map[Action][]MouseButton   `json:"mouse"`
+	Buttons  map[Action][]GamepadButton `json:"buttons"`
+	Axes     map[Action][]GamepadAxis   `json:"axes"`
+	Analog   *AnalogConfig              `json:"analog,omitempty"`
+	MouseAim bool                       `json:"mouse_aim"`
+}
+
+func (b *Bindings) MarshalJSON() ([]byte, error) {
+	return json.Marshal(bindingsJSON{
+		Keys:     b.keys,
+		Mouse:    b.mouse,
+		Buttons:  b.buttons,
+		Axes:     b.axes,
+		Analog:   &b.analog,
+		MouseAim: b.mouseAim,
+	})
+}
+
+// UnmarshalJSON decodes bindings on top of the defaults, so actions and
+// sections missing from older files keep their default inputs. Files written
+// before gamepad support, a plain object of actions to keys, are accepted.
+func (b *Bindings) UnmarshalJSON(data []byte) error {
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(data, &fields); err != nil {
+		return err
+	}
+	var decoded bindingsJSON
+	if isLegacyBindings(fields) {
+		if err := json.Unmarshal(data, &decoded.Keys); err != nil {
+			return err
+		}
+	} else if err := json.Unmarshal(data, &decoded); err != nil {
+		return err
+	}
+
+	*b = *DefaultBindings()
+	maps.Copy(b.keys, decoded.Keys)
+	maps.Copy(b.mouse, decoded.Mouse)
+	maps.Copy(b.buttons, decoded.Buttons)
+	maps.Copy(b.axes, decoded.Axes)
+	if decoded.Analog != nil {
+		b.analog = *decoded.Analog
+	}
+	b.mouseAim = decoded.MouseAim
+	return nil
+}
+
+// isLegacyBindings reports whether an encoded Bindings object uses the
+// key-only format, whose fields are action names.
+func isLegacyBindings(fields map[string]json.RawMessage) bool {
+	for _, section := range []string{"keys", "mouse", "buttons", "axes", "analog", "mouse_aim"} {
+		if _, ok := fields[section]; ok {
+			return false
+		}
+	}
+	return len(fields) > 0
+}
+
+// Profiles is a set of named binding profiles with one of them active.
+type Profiles struct {
+	Active   string               `json:"active"`
+	Profiles map[string]*Bindings `json:"profiles"`
+}
+
+// DefaultProfiles returns the built-in profiles: WASD and arrow keys for
+// left-handed players. Keys are physical positions on a US keyboard, so the
+// WASD profile is ZQSD on AZERTY keyboards without any remapping.
+func DefaultProfiles() *Profiles {
+	leftHanded := DefaultBindings()
+	leftHanded.Bind(ActionMoveForward, ebiten.KeyArrowUp)
+	leftHanded.Bind(ActionMoveBackward, ebiten.KeyArrowDown)
+	leftHanded.Bind(ActionTurnLeft, ebiten.KeyArrowLeft)
+	leftHanded.Bind(ActionTurnRight, ebiten.KeyArrowRight)
+	leftHanded.Bind(ActionFire, ebiten.KeyControlRight)
+	leftHanded.Bind(ActionMenuUp, ebiten.KeyArrowUp)
+	leftHanded.Bind(ActionMenuDown, ebiten.KeyArrowDown)
+	leftHanded.Bind(ActionMenuConfirm, ebiten.KeyEnter)
+
+	return &Profiles{
+		Active: DefaultProfile,
+		Profiles: map[string]*Bindings{
+			DefaultProfile: DefaultBindings(),
+			"left_handed":  leftHanded,
+		},
+	}
+}
+
+// Names returns the profile names in sorted order.
+func (p *Profiles) Names() []string {
+	names := make([]string, 0, len(p.Profiles))
+	for name := range p.Profiles {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+	return names
+}
+
+// Bindings returns the active profile, creating it from the defaults if it
+// does not exist.
+func (p *Profiles) Bindings() *Bindings {
+	if p.Profiles == nil {
+		p.Profiles = make(map[string]*Bindings)
+	}
+	b, ok := p.Profiles[p.Active]
+	if !ok || b == nil {
+		b = DefaultBindings()
+		p.Profiles[p.Active] = b
+	}
+	return b
+}
+
+// ProfilePath returns the location of the controls file in the user's
+// config directory.
+func ProfilePath() (string, error) {
+	dir, err := os.UserConfigDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, "tankismus", "controls.json"), nil
+}
+
+// LoadProfiles reads binding profiles from path. A missing file is not an
+// error; the built-in profiles are returned instead.
+func LoadProfiles(path string) (*Profiles, error) {
+	data, err := os.ReadFile(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return DefaultProfiles(), nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	var p Profiles
+	if err := json.Unmarshal(data, &p); err != nil {
+		return nil, fmt.Errorf("input: decoding %s: %w", path, err)
+	}
+	return &p, nil
+}
+
+// Save writes the profiles to path, creating its directory if needed. The
+// file is replaced atomically so a crash cannot leave it half written.
+func (p *Profiles) Save(path string) error {
+	data, err := json.MarshalIndent(p, "", "  ")
+	if err != nil {
+		return err
+	}
+	return writeFileAtomic(path, data)
+}
+
+// writeFileAtomic writes data to a temporary file next to path and renames
+// it into place, creating the directory if needed.
+func writeFileAtomic(path string, data []byte) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return err
+	}
+	tmp, err := os.CreateTemp(filepath.Dir(path), "."+filepath.Base(path)+"-*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), path)
+}
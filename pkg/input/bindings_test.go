@@ -0,0 +1,168 @@
+package input
+
+import (
+	"math"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/hajimehoshi/ebiten/v2"
+)
+
+func TestBindings_DefaultsHaveNoConflicts(t *testing.T) {
+	for name, b := range DefaultProfiles().Profiles {
+		if conflicts := b.Conflicts(); len(conflicts) > 0 {
+			t.Errorf("profile %q has conflicts: %v", name, conflicts)
+		}
+	}
+}
+
+func TestBindings_ConflictsOnlyWithinAGroup(t *testing.T) {
+	b := DefaultBindings()
+
+	// W moving the tank and navigating menus is intended.
+	if conflicts := b.Conflicts(); len(conflicts) != 0 {
+		t.Fatalf("default bindings report conflicts %v", conflicts)
+	}
+
+	conflicts := b.Bind(ActionFire, ebiten.KeyW)
+	want := []Conflict{{Key: ebiten.KeyW, Actions: []Action{ActionMoveForward, ActionFire}}}
+	if !reflect.DeepEqual(conflicts, want) {
+		t.Fatalf("Bind conflicts = %v, want %v", conflicts, want)
+	}
+}
+
+func TestProfiles_SaveLoadRoundTrip(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "nested", "controls.json")
+
+	if p, err := LoadProfiles(path); err != nil || p.Active != DefaultProfile {
+		t.Fatalf("LoadProfiles(missing) = %+v, %v; want built-in profiles", p, err)
+	}
+
+	p := DefaultProfiles()
+	p.Active = "left_handed"
+	p.Bindings().Bind(ActionFire, ebiten.KeyF, ebiten.KeyControlRight)
+	if err := p.Save(path); err != nil {
+		t.Fatalf("Save: %v", err)
+	}
+
+	loaded, err := LoadProfiles(path)
+	if err != nil {
+		t.Fatalf("LoadProfiles: %v", err)
+	}
+	if loaded.Active != "left_handed" {
+		t.Fatalf("Active = %q, want left_handed", loaded.Active)
+	}
+	for _, a := range Actions() {
+		if got, want := loaded.Bindings().Keys(a), p.Bindings().Keys(a); !reflect.DeepEqual(got, want) {
+			t.Errorf("%s keys = %v, want %v", a, got, want)
+		}
+	}
+}
+
+func TestBindings_UnmarshalKeepsDefaultsForMissingActions(t *testing.T) {
+	var b Bindings
+	if err := b.UnmarshalJSON([]byte(`{"fire": ["F"]}`)); err != nil {
+		t.Fatalf("UnmarshalJSON: %v", err)
+	}
+	if keys := b.Keys(ActionFire); !reflect.DeepEqual(keys, []ebiten.Key{ebiten.KeyF}) {
+		t.Fatalf("fire = %v, want [F]", keys)
+	}
+	if keys := b.Keys(ActionMoveForward); !reflect.DeepEqual(keys, []ebiten.Key{ebiten.KeyW}) {
+		t.Fatalf("move_forward = %v, want default [W]", keys)
+	}
+}
+
+func TestBindings_GamepadRoundTripAndLegacyFormat(t *testing.T) {
+	b := DefaultBindings()
+	b.BindButtons(ActionFire, GamepadButton(ebiten.StandardGamepadButtonRightRight))
+	b.BindAxes(ActionTurnLeft, GamepadAxis{Axis: ebiten.StandardGamepadAxisRightStickHorizontal})
+	b.SetAnalog(AnalogConfig{Deadzone: 0.3, Exponent: 2})
+
+	data, err := b.MarshalJSON()
+	if err != nil {
+		t.Fatalf("MarshalJSON: %v", err)
+	}
+	var decoded Bindings
+	if err := decoded.UnmarshalJSON(data); err != nil {
+		t.Fatalf("UnmarshalJSON(%s): %v", data, err)
+	}
+	if got := decoded.DescribeGamepad(ActionFire); got != "right_right" {
+		t.Errorf("fire gamepad = %q, want right_right", got)
+	}
+	if got := decoded.DescribeGamepad(ActionTurnLeft); got != "left_left, right_x-" {
+		t.Errorf("turn_left gamepad = %q, want left_left, right_x-", got)
+	}
+	if got := decoded.Analog(); got != (AnalogConfig{Deadzone: 0.3, Exponent: 2}) {
+		t.Errorf("analog = %+v", got)
+	}
+
+	// Files written before gamepad support only contain keys.
+	var legacy Bindings
+	if err := legacy.UnmarshalJSON([]byte(`{"fire": ["F"]}`)); err != nil {
+		t.Fatalf("UnmarshalJSON(legacy): %v", err)
+	}
+	if got, want := legacy.Buttons(ActionFire), DefaultBindings().Buttons(ActionFire); !reflect.DeepEqual(got, want) {
+		t.Errorf("legacy fire buttons = %v, want defaults %v", got, want)
+	}
+}
+
+func TestAnalogConfig_Apply(t *testing.T) {
+	c := AnalogConfig{Deadzone: 0.2, Exponent: 2}
+	tests := []struct{ in, want float64 }{
+		{0, 0},
+		{0.2, 0},
+		{0.6, 0.25},
+		{1, 1},
+		{1.5, 1},
+		{-1, 0},
+	}
+	for _, tt := range tests {
+		if got := c.Apply(tt.in); math.Abs(got-tt.want) > 1e-9 {
+			t.Errorf("Apply(%v) = %v, want %v", tt.in, got, tt.want)
+		}
+	}
+	if got := (AnalogConfig{}).Apply(0.4); got != 0.4 {
+		t.Errorf("zero config Apply(0.4) = %v, want linear 0.4", got)
+	}
+}
+
+func TestGamepadAxis_Text(t *testing.T) {
+	for _, name := range []string{"left_x-", "left_y+", "right_x+", "right_y-"} {
+		var a GamepadAxis
+		if err := a.UnmarshalText([]byte(name)); err != nil {
+			t.Fatalf("UnmarshalText(%q): %v", name, err)
+		}
+		if a.String() != name {
+			t.Errorf("round trip %q = %q", name, a.String())
+		}
+	}
+	var a GamepadAxis
+	if err := a.UnmarshalText([]byte("left_z+")); err == nil {
+		t.Error("UnmarshalText(left_z+) succeeded, want error")
+	}
+}
+
+func TestBindings_MouseRoundTrip(t *testing.T) {
+	b := DefaultBindings()
+	if got := b.MouseButtons(ActionFire); !reflect.DeepEqual(got, []MouseButton{MouseButton(ebiten.MouseButtonLeft)}) {
+		t.Fatalf("default fire mouse buttons = %v, want [mouse_left]", got)
+	}
+	b.BindMouse(ActionFire, MouseButton(ebiten.MouseButtonRight))
+	b.SetMouseAim(true)
+
+	data, err := b.MarshalJSON()
+	if err != nil {
+		t.Fatalf("MarshalJSON: %v", err)
+	}
+	var decoded Bindings
+	if err := decoded.UnmarshalJSON(data); err != nil {
+		t.Fatalf("UnmarshalJSON(%s): %v", data, err)
+	}
+	if got := decoded.MouseButtons(ActionFire); !reflect.DeepEqual(got, []MouseButton{MouseButton(ebiten.MouseButtonRight)}) {
+		t.Errorf("fire mouse buttons = %v, want [mouse_right]", got)
+	}
+	if !decoded.MouseAim() {
+		t.Error("mouse aim not restored")
+	}
+}
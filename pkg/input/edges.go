@@ -0,0 +1,37 @@
+package input
+
+// actionEdges derives press and release edges and hold durations from the
+// down state of each action, sampled once per Poll.
+type actionEdges struct {
+	// durations counts the consecutive polls an action has been down.
+	durations map[Action]int
+	// released marks actions that went up in the latest poll.
+	released map[Action]bool
+}
+
+func newActionEdges() actionEdges {
+	return actionEdges{durations: make(map[Action]int), released: make(map[Action]bool)}
+}
+
+// update records whether a was down in the current poll.
+func (e actionEdges) update(a Action, down bool) {
+	if down {
+		e.durations[a]++
+		e.released[a] = false
+		return
+	}
+	e.released[a] = e.durations[a] > 0
+	e.durations[a] = 0
+}
+
+func (e actionEdges) justPressed(a Action) bool {
+	return e.durations[a] == 1
+}
+
+func (e actionEdges) justReleased(a Action) bool {
+	return e.released[a]
+}
+
+func (e actionEdges) duration(a Action) int {
+	return e.durations[a]
+}
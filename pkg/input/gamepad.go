@@ -0,0 +1,142 @@
+package input
+
+import (
+	"fmt"
+	"math"
+	"strings"
+
+	"github.com/hajimehoshi/ebiten/v2"
+)
+
+// GamepadButton is a button in the standard gamepad layout. Triggers are
+// buttons with analog values. It encodes to JSON by name, for example
+// "right_bottom" for the A / Cross button.
+type GamepadButton ebiten.StandardGamepadButton
+
+var buttonNames = map[GamepadButton]string{
+	GamepadButton(ebiten.StandardGamepadButtonRightBottom):      "right_bottom",
+	GamepadButton(ebiten.StandardGamepadButtonRightRight):       "right_right",
+	GamepadButton(ebiten.StandardGamepadButtonRightLeft):        "right_left",
+	GamepadButton(ebiten.StandardGamepadButtonRightTop):         "right_top",
+	GamepadButton(ebiten.StandardGamepadButtonFrontTopLeft):     "front_top_left",
+	GamepadButton(ebiten.StandardGamepadButtonFrontTopRight):    "front_top_right",
+	GamepadButton(ebiten.StandardGamepadButtonFrontBottomLeft):  "front_bottom_left",
+	GamepadButton(ebiten.StandardGamepadButtonFrontBottomRight): "front_bottom_right",
+	GamepadButton(ebiten.StandardGamepadButtonCenterLeft):       "center_left",
+	GamepadButton(ebiten.StandardGamepadButtonCenterRight):      "center_right",
+	GamepadButton(ebiten.StandardGamepadButtonLeftStick):        "left_stick",
+	GamepadButton(ebiten.StandardGamepadButtonRightStick):       "right_stick",
+	GamepadButton(ebiten.StandardGamepadButtonLeftTop):          "left_top",
+	GamepadButton(ebiten.StandardGamepadButtonLeftBottom):       "left_bottom",
+	GamepadButton(ebiten.StandardGamepadButtonLeftLeft):         "left_left",
+	GamepadButton(ebiten.StandardGamepadButtonLeftRight):        "left_right",
+	GamepadButton(ebiten.StandardGamepadButtonCenterCenter):     "center_center",
+}
+
+func (b GamepadButton) String() string {
+	if name, ok := buttonNames[b]; ok {
+		return name
+	}
+	return fmt.Sprintf("button%d", int(b))
+}
+
+func (b GamepadButton) MarshalText() ([]byte, error) {
+	return []byte(b.String()), nil
+}
+
+func (b *GamepadButton) UnmarshalText(text []byte) error {
+	for button, name := range buttonNames {
+		if name == string(text) {
+			*b = button
+			return nil
+		}
+	}
+	return fmt.Errorf("input: unknown gamepad button %q", text)
+}
+
+// GamepadAxis is one direction of a stick axis in the standard gamepad
+// layout. It encodes to JSON as the axis name with a sign, for example
+// "left_y-" for pushing the left stick up.
+type GamepadAxis struct {
+	Axis     ebiten.StandardGamepadAxis
+	Positive bool
+}
+
+var axisNames = map[ebiten.StandardGamepadAxis]string{
+	ebiten.StandardGamepadAxisLeftStickHorizontal:  "left_x",
+	ebiten.StandardGamepadAxisLeftStickVertical:    "left_y",
+	ebiten.StandardGamepadAxisRightStickHorizontal: "right_x",
+	ebiten.StandardGamepadAxisRightStickVertical:   "right_y",
+}
+
+func (a GamepadAxis) String() string {
+	sign := "-"
+	if a.Positive {
+		sign = "+"
+	}
+	return axisNames[a.Axis] + sign
+}
+
+func (a GamepadAxis) MarshalText() ([]byte, error) {
+	return []byte(a.String()), nil
+}
+
+func (a *GamepadAxis) UnmarshalText(text []byte) error {
+	s := string(text)
+	if len(s) > 1 {
+		name, sign := s[:len(s)-1], s[len(s)-1]
+		for axis, n := range axisNames {
+			if n == name && (sign == '+' || sign == '-') {
+				*a = GamepadAxis{Axis: axis, Positive: sign == '+'}
+				return nil
+			}
+		}
+	}
+	return fmt.Errorf("input: unknown gamepad axis %q", text)
+}
+
+// value returns how far the axis is pushed in this direction, from 0 to 1.
+func (a GamepadAxis) value(raw float64) float64 {
+	if !a.Positive {
+		raw = -raw
+	}
+	return math.Max(raw, 0)
+}
+
+// AnalogConfig shapes raw stick and trigger values.
+type AnalogConfig struct {
+	// Deadzone is the magnitude below which input is ignored. The remaining
+	// range is rescaled so values still start at 0 and end at 1.
+	Deadzone float64 `json:"deadzone"`
+	// Exponent is the response curve: 1 is linear, larger values give finer
+	// control near the centre. Zero is treated as 1.
+	Exponent float64 `json:"exponent"`
+}
+
+// DefaultAnalog is the analog configuration of the built-in profiles.
+var DefaultAnalog = AnalogConfig{Deadzone: 0.15, Exponent: 1.5}
+
+// Apply maps a raw value in [0, 1] through the deadzone and response curve.
+func (c AnalogConfig) Apply(v float64) float64 {
+	v = math.Min(math.Max(v, 0), 1)
+	if v <= c.Deadzone || c.Deadzone >= 1 {
+		return 0
+	}
+	v = (v - c.Deadzone) / (1 - c.Deadzone)
+	if c.Exponent > 0 {
+		v = math.Pow(v, c.Exponent)
+	}
+	return v
+}
+
+// describeGamepad formats gamepad inputs for settings screens.
+func describeGamepad(buttons []GamepadButton, axes []GamepadAxis) string {
+	parts := make([]string, 0, len(buttons)+len(axes))
+	for _, b := range buttons {
+		parts = append(parts, b.String())
+	}
+	for _, a := range axes {
+		parts = append(parts, a.String())
+	}
+	return strings.Join(parts, ", ")
+}
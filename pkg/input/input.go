@@ -1,6 +1,8 @@
 package input
 
 import (
+	"slices"
+
 	"github.com/hajimehoshi/ebiten/v2"
 	"github.com/hajimehoshi/ebiten/v2/inpututil"
 )
@@ -14,71 +16,299 @@ const (
 	ActionTurnLeft     Action = "turn_left"
 	ActionTurnRight    Action = "turn_right"
 	ActionFire         Action = "fire"
+	ActionPause        Action = "pause"
+	ActionMenuUp       Action = "menu_up"
+	ActionMenuDown     Action = "menu_down"
+	ActionMenuConfirm  Action = "menu_confirm"
+	ActionDebugToggle  Action = "debug_toggle"
 )
 
-// mapping from actions to ebiten keys.
-var actionBindings = map[Action][]ebiten.Key{
-	ActionMoveForward:  {ebiten.KeyW},
-	ActionMoveBackward: {ebiten.KeyS},
-	ActionTurnLeft:     {ebiten.KeyA},
-	ActionTurnRight:    {ebiten.KeyD},
-	ActionFire:         {ebiten.KeySpace},
-}
+// pressThreshold is the action value at which an analog input counts as
+// pressed for IsActionDown.
+const pressThreshold = 0.5
 
 // Manager abstracts input management so production code can use Ebiten-backed
 // input while tests can install a fake implementation.
 type Manager interface {
 	Poll()
 	IsActionDown(Action) bool
+	// ActionValue returns how strongly an action is applied, from 0 to 1.
+	// Keys and digital buttons yield 0 or 1; sticks and triggers yield
+	// values in between after deadzone and response curve.
+	ActionValue(Action) float64
+	// IsActionJustPressed reports whether an action went down in the latest
+	// Poll, and IsActionJustReleased whether it went up.
+	IsActionJustPressed(Action) bool
+	IsActionJustReleased(Action) bool
+	// ActionPressDuration returns for how many consecutive polls an action
+	// has been down, or 0 when it is up.
+	ActionPressDuration(Action) int
+	// CursorPosition returns the mouse cursor position in screen pixels as
+	// of the latest Poll.
+	CursorPosition() (x, y float64)
+	// IsMouseButtonJustPressed reports whether a mouse button went down in
+	// the latest Poll, even while mouse actions are suppressed.
+	IsMouseButtonJustPressed(MouseButton) bool
+	// JustPressedKeys returns the keys that went down in the latest Poll,
+	// whether or not they are bound, for capturing a new binding.
+	JustPressedKeys() []ebiten.Key
+	// MouseAim reports whether the mouse aim control scheme was selected as
+	// of the latest Poll.
+	MouseAim() bool
 	AnyKeyPressed() bool
 }
 
-// ebitenManager uses Ebiten's keyboard state as the input source.
+// ebitenManager uses Ebiten's keyboard, mouse and standard-layout gamepad
+// state as the input source.
 type ebitenManager struct {
-	state map[Action]bool
+	values           map[Action]float64
+	edges            actionEdges
+	gamepads         []ebiten.GamepadID
+	cursorX, cursorY float64
+	clicks           mouseClicks
+	keys             []ebiten.Key
+	mouseAim         bool
 }
 
 func newEbitenManager() *ebitenManager {
 	return &ebitenManager{
-		state: make(map[Action]bool),
+		values: make(map[Action]float64),
+		edges:  newActionEdges(),
 	}
 }
 
 func (m *ebitenManager) Poll() {
-	for action, keys := range actionBindings {
-		pressed := false
-		for _, k := range keys {
-			if ebiten.IsKeyPressed(k) {
-				pressed = true
-				break
+	m.gamepads = ebiten.AppendGamepadIDs(m.gamepads[:0])
+	x, y := ebiten.CursorPosition()
+	m.cursorX, m.cursorY = float64(x), float64(y)
+	var held uint32
+	for b := range ebiten.MouseButtonMax + 1 {
+		if ebiten.IsMouseButtonPressed(b) {
+			held |= 1 << b
+		}
+	}
+	m.clicks.update(held)
+	m.keys = inpututil.AppendJustPressedKeys(m.keys[:0])
+	m.mouseAim = bindings.mouseAim
+	for _, action := range Actions() {
+		m.values[action] = m.poll(action)
+		m.edges.update(action, m.IsActionDown(action))
+	}
+}
+
+// poll returns the strongest value of the inputs bound to an action across
+// all connected gamepads with a standard layout.
+func (m *ebitenManager) poll(a Action) float64 {
+	for _, k := range bindings.keys[a] {
+		if ebiten.IsKeyPressed(k) {
+			return 1
+		}
+	}
+	if !mouseActionsSuppressed {
+		for _, b := range bindings.mouse[a] {
+			if ebiten.IsMouseButtonPressed(ebiten.MouseButton(b)) {
+				return 1
 			}
 		}
-		m.state[action] = pressed
 	}
+	value := 0.0
+	for _, id := range m.gamepads {
+		if !ebiten.IsStandardGamepadLayoutAvailable(id) {
+			continue
+		}
+		for _, b := range bindings.buttons[a] {
+			raw := ebiten.StandardGamepadButtonValue(id, ebiten.StandardGamepadButton(b))
+			value = max(value, bindings.analog.Apply(raw))
+		}
+		for _, axis := range bindings.axes[a] {
+			raw := ebiten.StandardGamepadAxisValue(id, axis.Axis)
+			value = max(value, bindings.analog.Apply(axis.value(raw)))
+		}
+	}
+	return value
 }
 
 func (m *ebitenManager) IsActionDown(a Action) bool {
-	return m.state[a]
+	return m.values[a] >= pressThreshold
+}
+
+func (m *ebitenManager) ActionValue(a Action) float64 {
+	return m.values[a]
+}
+
+func (m *ebitenManager) IsActionJustPressed(a Action) bool {
+	return m.edges.justPressed(a)
+}
+
+func (m *ebitenManager) IsActionJustReleased(a Action) bool {
+	return m.edges.justReleased(a)
+}
+
+func (m *ebitenManager) ActionPressDuration(a Action) int {
+	return m.edges.duration(a)
+}
+
+func (m *ebitenManager) CursorPosition() (x, y float64) {
+	return m.cursorX, m.cursorY
+}
+
+func (m *ebitenManager) IsMouseButtonJustPressed(b MouseButton) bool {
+	return m.clicks.justPressed(b)
+}
+
+func (m *ebitenManager) JustPressedKeys() []ebiten.Key {
+	return slices.Clone(m.keys)
+}
+
+func (m *ebitenManager) MouseAim() bool {
+	return m.mouseAim
 }
 
 func (m *ebitenManager) AnyKeyPressed() bool {
-	return len(inpututil.PressedKeys()) > 0
+	if len(inpututil.PressedKeys()) > 0 {
+		return true
+	}
+	for _, id := range ebiten.AppendGamepadIDs(nil) {
+		for b := range ebiten.StandardGamepadButtonMax + 1 {
+			if ebiten.IsStandardGamepadButtonPressed(id, b) {
+				return true
+			}
+		}
+	}
+	return false
 }
 
-// TestManager is a simple in-memory Manager suitable for tests.
+// TestManager is a simple in-memory Manager suitable for tests. State holds
+// digital actions; Values holds analog actions and takes precedence over
+// State in ActionValue. IsActionDown reads them directly, while the edge and
+// duration queries only change on Poll, so tests can simulate frame-by-frame
+// press sequences by editing State between polls. CursorX and CursorY are
+// the cursor position in screen pixels. Mouse holds the pressed mouse
+// buttons, which drive the actions they are bound to unless mouse actions are
+// suppressed. Keys holds held keyboard keys; they only feed JustPressedKeys,
+// so use State to drive actions. MouseAim follows the active bindings like
+// the Ebiten-backed manager.
 type TestManager struct {
-	State map[Action]bool
+	State            map[Action]bool
+	Values           map[Action]float64
+	Mouse            map[MouseButton]bool
+	Keys             map[ebiten.Key]bool
+	CursorX, CursorY float64
+
+	edges       actionEdges
+	clicks      mouseClicks
+	heldKeys    map[ebiten.Key]bool
+	pressedKeys []ebiten.Key
+	mouseAim    bool
 }
 
-// NewTestManager constructs a TestManager with an empty state map.
+// NewTestManager constructs a TestManager with empty state maps.
 func NewTestManager() *TestManager {
-	return &TestManager{State: make(map[Action]bool)}
+	return &TestManager{
+		State:  make(map[Action]bool),
+		Values: make(map[Action]float64),
+		Mouse:  make(map[MouseButton]bool),
+		Keys:   make(map[ebiten.Key]bool),
+		edges:  newActionEdges(),
+	}
 }
 
-func (m *TestManager) Poll() {}
+// Poll samples State and Values as one frame of input.
+func (m *TestManager) Poll() {
+	if m.edges.durations == nil {
+		m.edges = newActionEdges()
+	}
+	var held uint32
+	for b, down := range m.Mouse {
+		if down && b >= 0 && b < 32 {
+			held |= 1 << b
+		}
+	}
+	m.clicks.update(held)
+	m.pressedKeys = m.pressedKeys[:0]
+	for k, down := range m.Keys {
+		if down && !m.heldKeys[k] {
+			m.pressedKeys = append(m.pressedKeys, k)
+		}
+	}
+	slices.Sort(m.pressedKeys)
+	m.heldKeys = make(map[ebiten.Key]bool, len(m.Keys))
+	for k, down := range m.Keys {
+		m.heldKeys[k] = down
+	}
+	m.mouseAim = bindings.mouseAim
+	actions := make(map[Action]bool)
+	for a := range m.State {
+		actions[a] = true
+	}
+	for a := range bindings.mouse {
+		actions[a] = true
+	}
+	for a := range m.Values {
+		actions[a] = true
+	}
+	for a := range m.edges.durations {
+		actions[a] = true
+	}
+	for a := range actions {
+		m.edges.update(a, m.IsActionDown(a))
+	}
+}
 
 func (m *TestManager) IsActionDown(a Action) bool {
-	return m.State[a]
+	return m.State[a] || m.mouseDown(a) || m.Values[a] >= pressThreshold
+}
+
+func (m *TestManager) ActionValue(a Action) float64 {
+	if v, ok := m.Values[a]; ok {
+		return v
+	}
+	if m.State[a] || m.mouseDown(a) {
+		return 1
+	}
+	return 0
+}
+
+// mouseDown reports whether a held button in Mouse is bound to a and mouse
+// actions are not suppressed.
+func (m *TestManager) mouseDown(a Action) bool {
+	if mouseActionsSuppressed {
+		return false
+	}
+	for _, b := range bindings.mouse[a] {
+		if m.Mouse[b] {
+			return true
+		}
+	}
+	return false
+}
+
+func (m *TestManager) IsActionJustPressed(a Action) bool {
+	return m.edges.justPressed(a)
+}
+
+func (m *TestManager) IsActionJustReleased(a Action) bool {
+	return m.edges.justReleased(a)
+}
+
+func (m *TestManager) ActionPressDuration(a Action) int {
+	return m.edges.duration(a)
+}
+
+func (m *TestManager) CursorPosition() (x, y float64) {
+	return m.CursorX, m.CursorY
+}
+
+func (m *TestManager) IsMouseButtonJustPressed(b MouseButton) bool {
+	return m.clicks.justPressed(b)
+}
+
+func (m *TestManager) JustPressedKeys() []ebiten.Key {
+	return slices.Clone(m.pressedKeys)
+}
+
+func (m *TestManager) MouseAim() bool {
+	return m.mouseAim
 }
 
 func (m *TestManager) AnyKeyPressed() bool {
@@ -87,14 +317,47 @@ func (m *TestManager) AnyKeyPressed() bool {
 			return true
 		}
 	}
+	for _, down := range m.Keys {
+		if down {
+			return true
+		}
+	}
 	return false
 }
 
 var (
 	defaultManager Manager = newEbitenManager()
 	manager        Manager = defaultManager
+
+	// bindings maps actions to inputs for the Ebiten-backed manager.
+	bindings = DefaultBindings()
+
+	// mouseActionsSuppressed stops mouse buttons from driving actions.
+	mouseActionsSuppressed bool
 )
 
+// SetBindings replaces the active input bindings. Passing nil restores the
+// defaults. Later changes to b through Bind take effect on the next Poll.
+func SetBindings(b *Bindings) {
+	if b == nil {
+		b = DefaultBindings()
+	}
+	bindings = b
+}
+
+// CurrentBindings returns the active input bindings.
+func CurrentBindings() *Bindings {
+	return bindings
+}
+
+// SuppressMouseActions stops mouse buttons from driving actions while
+// suppress is true, for overlays that take clicks themselves such as the
+// debug inspector. Clicks are still reported by IsMouseButtonJustPressed.
+// Playback replays the recorded action values and ignores it.
+func SuppressMouseActions(suppress bool) {
+	mouseActionsSuppressed = suppress
+}
+
 // SetManager replaces the current input manager. Passing nil restores the
 // default Ebiten-backed manager. This is primarily intended for tests.
 func SetManager(m Manager) {
@@ -115,7 +378,52 @@ func IsActionDown(a Action) bool {
 	return manager.IsActionDown(a)
 }
 
-// AnyKeyPressed reports whether any key was pressed in the current frame.
+// ActionValue returns how strongly the given action is applied, from 0 to 1.
+func ActionValue(a Action) float64 {
+	return manager.ActionValue(a)
+}
+
+// IsActionJustPressed reports whether the given action went down in the
+// latest Poll. It is true for exactly one frame per press.
+func IsActionJustPressed(a Action) bool {
+	return manager.IsActionJustPressed(a)
+}
+
+// IsActionJustReleased reports whether the given action went up in the
+// latest Poll.
+func IsActionJustReleased(a Action) bool {
+	return manager.IsActionJustReleased(a)
+}
+
+// ActionPressDuration returns for how many consecutive polls the given action
+// has been down, or 0 when it is up. The game polls once per tick, so this is
+// the hold time in ticks.
+func ActionPressDuration(a Action) int {
+	return manager.ActionPressDuration(a)
+}
+
+// CursorPosition returns the mouse cursor position in screen pixels as of the
+// latest Poll. Game code converts it to world coordinates with the camera.
+func CursorPosition() (x, y float64) {
+	return manager.CursorPosition()
+}
+
+// IsMouseButtonJustPressed reports whether the given mouse button went down
+// in the latest Poll. Together with CursorPosition it lets tools handle
+// clicks through the recorded input.
+func IsMouseButtonJustPressed(b MouseButton) bool {
+	return manager.IsMouseButtonJustPressed(b)
+}
+
+// MouseAim reports whether the mouse aim control scheme was selected as of
+// the latest Poll. Game code asks the manager rather than the bindings so
+// that recordings replay with the scheme they were made with.
+func MouseAim() bool {
+	return manager.MouseAim()
+}
+
+// AnyKeyPressed reports whether any key or gamepad button was pressed in the
+// current frame.
 // This is useful for simple "press any key" screens while still keeping
 // Ebiten-specific details inside the input package.
 func AnyKeyPressed() bool {
@@ -129,3 +437,10 @@ func ShouldQuit() bool {
 	// Require Control to be held and C pressed in the current frame.
 	return ebiten.IsKeyPressed(ebiten.KeyControl) && inpututil.IsKeyJustPressed(ebiten.KeyC)
 }
+
+// JustPressedKeys returns the keys that went down in the latest Poll, for
+// example to capture a new binding on a controls screen. Like the actions it
+// comes from the active Manager, so tests and replays can drive rebinding.
+func JustPressedKeys() []ebiten.Key {
+	return manager.JustPressedKeys()
+}
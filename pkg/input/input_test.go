@@ -0,0 +1,102 @@
+package input
+
+import (
+	"testing"
+
+	"github.com/hajimehoshi/ebiten/v2"
+)
+
+func TestTestManager_TracksEdgesAndHoldDuration(t *testing.T) {
+	m := NewTestManager()
+
+	// Each entry is one frame: whether fire is held and what the edge and
+	// duration queries must report after polling it.
+	frames := []struct {
+		down              bool
+		pressed, released bool
+		duration          int
+	}{
+		{down: false},
+		{down: true, pressed: true, duration: 1},
+		{down: true, duration: 2},
+		{down: true, duration: 3},
+		{down: false, released: true},
+		{down: false},
+		{down: true, pressed: true, duration: 1},
+	}
+	for i, f := range frames {
+		m.State[ActionFire] = f.down
+		m.Poll()
+		if got := m.IsActionJustPressed(ActionFire); got != f.pressed {
+			t.Errorf("frame %d: IsActionJustPressed = %v, want %v", i, got, f.pressed)
+		}
+		if got := m.IsActionJustReleased(ActionFire); got != f.released {
+			t.Errorf("frame %d: IsActionJustReleased = %v, want %v", i, got, f.released)
+		}
+		if got := m.ActionPressDuration(ActionFire); got != f.duration {
+			t.Errorf("frame %d: ActionPressDuration = %d, want %d", i, got, f.duration)
+		}
+	}
+}
+
+func TestTestManager_EdgesOnlyChangeOnPoll(t *testing.T) {
+	m := NewTestManager()
+	m.State[ActionPause] = true
+	if m.IsActionJustPressed(ActionPause) {
+		t.Fatal("press reported before Poll")
+	}
+	m.Poll()
+	delete(m.State, ActionPause)
+	if !m.IsActionJustPressed(ActionPause) {
+		t.Fatal("press lost before the next Poll")
+	}
+	m.Poll()
+	if !m.IsActionJustReleased(ActionPause) {
+		t.Fatal("removing an action from State did not release it")
+	}
+}
+
+func TestTestManager_AnalogValuesPressAtThreshold(t *testing.T) {
+	m := NewTestManager()
+	m.Values[ActionMoveForward] = 0.4
+	m.Poll()
+	if m.IsActionDown(ActionMoveForward) || m.IsActionJustPressed(ActionMoveForward) {
+		t.Fatal("value below the threshold counts as pressed")
+	}
+	m.Values[ActionMoveForward] = 0.8
+	m.Poll()
+	if !m.IsActionJustPressed(ActionMoveForward) {
+		t.Fatal("value above the threshold is not a press")
+	}
+	if got := m.ActionValue(ActionMoveForward); got != 0.8 {
+		t.Fatalf("ActionValue = %v, want 0.8", got)
+	}
+}
+
+func TestTestManager_MouseButtonsClickAndDriveBoundActions(t *testing.T) {
+	defer SuppressMouseActions(false)
+	left := MouseButton(ebiten.MouseButtonLeft)
+
+	m := NewTestManager()
+	m.Mouse[left] = true
+	m.Poll()
+	if !m.IsMouseButtonJustPressed(left) || !m.IsActionJustPressed(ActionFire) {
+		t.Fatal("left click did not register as a click and a fire press")
+	}
+	m.Poll()
+	if m.IsMouseButtonJustPressed(left) {
+		t.Fatal("held mouse button clicked again")
+	}
+
+	m.Mouse[left] = false
+	m.Poll()
+	SuppressMouseActions(true)
+	m.Mouse[left] = true
+	m.Poll()
+	if !m.IsMouseButtonJustPressed(left) {
+		t.Fatal("suppressing mouse actions hid the click")
+	}
+	if m.IsActionDown(ActionFire) || m.IsActionJustPressed(ActionFire) {
+		t.Fatal("suppressed left click still fired")
+	}
+}
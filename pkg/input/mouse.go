@@ -0,0 +1,56 @@
+package input
+
+import (
+	"fmt"
+
+	"github.com/hajimehoshi/ebiten/v2"
+)
+
+// MouseButton is a mouse button that can be bound to an action. It encodes
+// to JSON by name, for example "mouse_left".
+type MouseButton ebiten.MouseButton
+
+var mouseButtonNames = map[MouseButton]string{
+	MouseButton(ebiten.MouseButtonLeft):   "mouse_left",
+	MouseButton(ebiten.MouseButtonMiddle): "mouse_middle",
+	MouseButton(ebiten.MouseButtonRight):  "mouse_right",
+	MouseButton(ebiten.MouseButton3):      "mouse_back",
+	MouseButton(ebiten.MouseButton4):      "mouse_forward",
+}
+
+func (b MouseButton) String() string {
+	if name, ok := mouseButtonNames[b]; ok {
+		return name
+	}
+	return fmt.Sprintf("mouse%d", int(b))
+}
+
+func (b MouseButton) MarshalText() ([]byte, error) {
+	return []byte(b.String()), nil
+}
+
+func (b *MouseButton) UnmarshalText(text []byte) error {
+	for button, name := range mouseButtonNames {
+		if name == string(text) {
+			*b = button
+			return nil
+		}
+	}
+	return fmt.Errorf("input: unknown mouse button %q", text)
+}
+
+// mouseClicks derives mouse button presses from the buttons held in each
+// Poll, kept as bit sets indexed by MouseButton.
+type mouseClicks struct {
+	down, pressed uint32
+}
+
+// update records the buttons held in the current poll.
+func (c *mouseClicks) update(down uint32) {
+	c.pressed = down &^ c.down
+	c.down = down
+}
+
+func (c mouseClicks) justPressed(b MouseButton) bool {
+	return b >= 0 && b < 32 && c.pressed&(1<<b) != 0
+}
@@ -0,0 +1,216 @@
+package input
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"slices"
+
+	"github.com/hajimehoshi/ebiten/v2"
+)
+
+// recordingVersion is the format version written by Recording.Save.
+const recordingVersion = 2
+
+// Frame is one Poll worth of recorded input: the value of every action that
+// was applied, the cursor position, the mouse buttons and keys that went
+// down, whether mouse aim was selected and the delta time of the game frame
+// it was polled in.
+type Frame struct {
+	DT       float64            `json:"dt"`
+	Values   map[Action]float64 `json:"values,omitempty"`
+	CursorX  float64            `json:"cursor_x,omitempty"`
+	CursorY  float64            `json:"cursor_y,omitempty"`
+	Clicks   []MouseButton      `json:"clicks,omitempty"`
+	Keys     []ebiten.Key       `json:"keys,omitempty"`
+	MouseAim bool               `json:"mouse_aim,omitempty"`
+}
+
+// Recording is a sequence of input frames, for example to attach a
+// reproducible input log to a bug report or to replay a session in tests.
+type Recording struct {
+	Version int     `json:"version"`
+	Frames  []Frame `json:"frames"`
+}
+
+// Save writes the recording as JSON to path, creating its directory if
+// needed.
+func (r *Recording) Save(path string) error {
+	r.Version = recordingVersion
+	data, err := json.Marshal(r)
+	if err != nil {
+		return err
+	}
+	return writeFileAtomic(path, data)
+}
+
+// LoadRecording reads a recording written by Recording.Save.
+func LoadRecording(path string) (*Recording, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var r Recording
+	if err := json.Unmarshal(data, &r); err != nil {
+		return nil, fmt.Errorf("input: decoding %s: %w", path, err)
+	}
+	if r.Version != recordingVersion {
+		return nil, fmt.Errorf("input: %s has recording version %d, want %d", path, r.Version, recordingVersion)
+	}
+	return &r, nil
+}
+
+// FrameTimer is implemented by managers that take part in frame timing. The
+// game loop passes each frame's delta time through BeginFrame before
+// updating scenes.
+type FrameTimer interface {
+	// BeginFrame is called once per game frame with the measured delta
+	// time and returns the delta time the frame should use.
+	BeginFrame(dt float64) float64
+}
+
+// BeginFrame announces a new game frame with the measured delta time dt and
+// returns the delta time to simulate. Recorder stores it with the next
+// polled frame and Playback substitutes the recorded one, so replays advance
+// exactly like the recorded session. Other managers return dt unchanged.
+func BeginFrame(dt float64) float64 {
+	if t, ok := manager.(FrameTimer); ok {
+		return t.BeginFrame(dt)
+	}
+	return dt
+}
+
+// Recorder is a Manager that forwards to another Manager and appends the
+// resulting action values to a Recording on every Poll.
+type Recorder struct {
+	Manager
+	dt        float64
+	recording Recording
+}
+
+// NewRecorder records the input of inner. Passing nil records the default
+// Ebiten-backed manager.
+func NewRecorder(inner Manager) *Recorder {
+	if inner == nil {
+		inner = defaultManager
+	}
+	return &Recorder{Manager: inner, recording: Recording{Version: recordingVersion}}
+}
+
+func (r *Recorder) BeginFrame(dt float64) float64 {
+	r.dt = dt
+	return dt
+}
+
+// Poll polls the wrapped manager and records the applied actions.
+func (r *Recorder) Poll() {
+	r.Manager.Poll()
+	frame := Frame{DT: r.dt, MouseAim: r.Manager.MouseAim()}
+	if keys := r.Manager.JustPressedKeys(); len(keys) > 0 {
+		frame.Keys = keys
+	}
+	frame.CursorX, frame.CursorY = r.Manager.CursorPosition()
+	for b := range MouseButton(ebiten.MouseButtonMax) + 1 {
+		if r.Manager.IsMouseButtonJustPressed(b) {
+			frame.Clicks = append(frame.Clicks, b)
+		}
+	}
+	for _, a := range Actions() {
+		if v := r.Manager.ActionValue(a); v > 0 {
+			if frame.Values == nil {
+				frame.Values = make(map[Action]float64)
+			}
+			frame.Values[a] = v
+		}
+	}
+	r.recording.Frames = append(r.recording.Frames, frame)
+}
+
+// Recording returns the frames recorded so far.
+func (r *Recorder) Recording() *Recording {
+	return &r.recording
+}
+
+// Playback is a Manager that replays a Recording, advancing one frame per
+// Poll. After the last frame every action is released.
+type Playback struct {
+	recording *Recording
+	next      int
+	current   Frame
+	edges     actionEdges
+}
+
+// NewPlayback replays rec from its first frame.
+func NewPlayback(rec *Recording) *Playback {
+	return &Playback{recording: rec, edges: newActionEdges()}
+}
+
+// Done reports whether every recorded frame has been polled.
+func (p *Playback) Done() bool {
+	return p.next >= len(p.recording.Frames)
+}
+
+// BeginFrame returns the recorded delta time of the frame the next Poll will
+// replay, or dt once the recording is exhausted.
+func (p *Playback) BeginFrame(dt float64) float64 {
+	if p.Done() {
+		return dt
+	}
+	return p.recording.Frames[p.next].DT
+}
+
+func (p *Playback) Poll() {
+	p.current = Frame{}
+	if !p.Done() {
+		p.current = p.recording.Frames[p.next]
+		p.next++
+	}
+	for _, a := range Actions() {
+		p.edges.update(a, p.IsActionDown(a))
+	}
+}
+
+func (p *Playback) IsActionDown(a Action) bool {
+	return p.current.Values[a] >= pressThreshold
+}
+
+func (p *Playback) ActionValue(a Action) float64 {
+	return p.current.Values[a]
+}
+
+func (p *Playback) IsActionJustPressed(a Action) bool {
+	return p.edges.justPressed(a)
+}
+
+func (p *Playback) IsActionJustReleased(a Action) bool {
+	return p.edges.justReleased(a)
+}
+
+func (p *Playback) ActionPressDuration(a Action) int {
+	return p.edges.duration(a)
+}
+
+func (p *Playback) CursorPosition() (x, y float64) {
+	return p.current.CursorX, p.current.CursorY
+}
+
+func (p *Playback) IsMouseButtonJustPressed(b MouseButton) bool {
+	return slices.Contains(p.current.Clicks, b)
+}
+
+func (p *Playback) JustPressedKeys() []ebiten.Key {
+	return slices.Clone(p.current.Keys)
+}
+
+// MouseAim returns the control scheme recorded with the current frame, so
+// replays do not depend on the local bindings.
+func (p *Playback) MouseAim() bool {
+	return p.current.MouseAim
+}
+
+// AnyKeyPressed reports true while frames remain. Screens that wait for any
+// key, such as the start screen, are never polled and therefore not part of
+// a recording, so a pending frame means the player moved past them.
+func (p *Playback) AnyKeyPressed() bool {
+	return !p.Done()
+}
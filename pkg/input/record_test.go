@@ -0,0 +1,104 @@
+package input
+
+import (
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/hajimehoshi/ebiten/v2"
+)
+
+func TestRecorder_PlaybackReproducesFrames(t *testing.T) {
+	tm := NewTestManager()
+	rec := NewRecorder(tm)
+
+	defer SetBindings(nil)
+	right := MouseButton(ebiten.MouseButtonRight)
+
+	script := []struct {
+		dt       float64
+		state    map[Action]bool
+		values   map[Action]float64
+		mouse    map[MouseButton]bool
+		keys     map[ebiten.Key]bool
+		mouseAim bool
+	}{
+		{dt: 0.016},
+		{dt: 0.017, state: map[Action]bool{ActionFire: true}, keys: map[ebiten.Key]bool{ebiten.KeyF5: true}},
+		{dt: 0.016, state: map[Action]bool{ActionFire: true}, values: map[Action]float64{ActionTurnLeft: 0.25}, mouseAim: true},
+		{dt: 0.018, mouse: map[MouseButton]bool{right: true}},
+	}
+	for _, f := range script {
+		b := DefaultBindings()
+		b.SetMouseAim(f.mouseAim)
+		SetBindings(b)
+		tm.State = f.state
+		tm.Values = f.values
+		tm.Mouse = f.mouse
+		tm.Keys = f.keys
+		if got := rec.BeginFrame(f.dt); got != f.dt {
+			t.Fatalf("Recorder.BeginFrame(%v) = %v", f.dt, got)
+		}
+		rec.Poll()
+	}
+
+	path := filepath.Join(t.TempDir(), "session.json")
+	if err := rec.Recording().Save(path); err != nil {
+		t.Fatalf("Save: %v", err)
+	}
+	loaded, err := LoadRecording(path)
+	if err != nil {
+		t.Fatalf("LoadRecording: %v", err)
+	}
+	if !reflect.DeepEqual(loaded, rec.Recording()) {
+		t.Fatalf("loaded recording = %+v, want %+v", loaded, rec.Recording())
+	}
+
+	// Playback ignores the local bindings.
+	SetBindings(nil)
+	p := NewPlayback(loaded)
+	var pressed, released []int
+	for i, f := range script {
+		if got := p.BeginFrame(1); got != f.dt {
+			t.Fatalf("frame %d: Playback.BeginFrame = %v, want recorded %v", i, got, f.dt)
+		}
+		p.Poll()
+		if p.IsActionJustPressed(ActionFire) {
+			pressed = append(pressed, i)
+		}
+		if p.IsActionJustReleased(ActionFire) {
+			released = append(released, i)
+		}
+		if got, want := p.ActionValue(ActionTurnLeft), f.values[ActionTurnLeft]; got != want {
+			t.Errorf("frame %d: turn_left = %v, want %v", i, got, want)
+		}
+		if got, want := p.IsMouseButtonJustPressed(right), f.mouse[right]; got != want {
+			t.Errorf("frame %d: right click = %v, want %v", i, got, want)
+		}
+		if got, want := p.JustPressedKeys(), f.keys[ebiten.KeyF5]; want != (len(got) == 1 && got[0] == ebiten.KeyF5) {
+			t.Errorf("frame %d: JustPressedKeys() = %v, want F5 %v", i, got, want)
+		}
+		if got := p.MouseAim(); got != f.mouseAim {
+			t.Errorf("frame %d: MouseAim() = %v, want recorded %v", i, got, f.mouseAim)
+		}
+	}
+	if !reflect.DeepEqual(pressed, []int{1}) || !reflect.DeepEqual(released, []int{3}) {
+		t.Errorf("fire pressed in frames %v and released in %v, want [1] and [3]", pressed, released)
+	}
+	if !p.Done() || p.AnyKeyPressed() {
+		t.Errorf("playback not done after the last frame")
+	}
+	if got := p.BeginFrame(0.02); got != 0.02 {
+		t.Errorf("BeginFrame after the recording = %v, want the measured 0.02", got)
+	}
+}
+
+func TestLoadRecording_RejectsUnknownVersion(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "session.json")
+	if err := writeFileAtomic(path, []byte(`{"version": 99, "frames": []}`)); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := LoadRecording(path); err == nil {
+		t.Fatal("LoadRecording accepted version 99")
+	}
+}
@@ -1,6 +1,10 @@
 package scene
 
-import "github.com/hajimehoshi/ebiten/v2"
+import (
+	"slices"
+
+	"github.com/hajimehoshi/ebiten/v2"
+)
 
 // Scene represents a high level game state such as start menu, gameplay, or game over.
 type Scene interface {
@@ -10,9 +14,44 @@ type Scene interface {
 	Draw(screen *ebiten.Image)
 }
 
-// Manager tracks the currently active scene.
+// Pauser is implemented by scenes that want to know when another scene is
+// pushed on top of them and when they become the top scene again.
+type Pauser interface {
+	OnPause()
+	OnResume()
+}
+
+// Mode controls how a pushed scene treats the scenes below it.
+type Mode int
+
+const (
+	// Freeze keeps drawing the scenes below but no longer updates them, for
+	// example a pause menu over the frozen game.
+	Freeze Mode = iota
+	// Live keeps drawing and updating the scenes below, for example a HUD
+	// message over the running game.
+	Live
+	// Cover hides the scenes below and stops updating them, for example a
+	// full-screen settings menu.
+	Cover
+)
+
+// layer is a scene on the stack together with the mode it was pushed with.
+type layer struct {
+	scene Scene
+	mode  Mode
+}
+
+// Manager maintains a stack of scenes. The top scene is the active one; the
+// scenes below keep drawing and updating as allowed by the modes of the
+// scenes pushed over them.
 type Manager struct {
-	current Scene
+	stack []layer
+
+	// transition is the running transition, if any.
+	transition *activeTransition
+	// fromImage and toImage are offscreen buffers reused across transitions.
+	fromImage, toImage *ebiten.Image
 }
 
 // NewManager constructs a new scene manager.
@@ -22,29 +61,119 @@ func NewManager(initial Scene) *Manager {
 	return m
 }
 
-// SetScene switches the active scene, calling lifecycle hooks.
+// SetScene replaces the whole stack with next, calling OnExit on every
+// scene from the top down before calling OnEnter on next. Passing nil
+// leaves the stack empty. A running transition is completed first.
 func (m *Manager) SetScene(next Scene) {
-	if m.current != nil {
-		m.current.OnExit()
+	m.finishTransition()
+	for len(m.stack) > 0 {
+		top := m.stack[len(m.stack)-1]
+		m.stack = m.stack[:len(m.stack)-1]
+		top.scene.OnExit()
+	}
+	if next != nil {
+		m.stack = append(m.stack, layer{scene: next, mode: Cover})
+		next.OnEnter()
 	}
-	m.current = next
-	if m.current != nil {
-		m.current.OnEnter()
+}
+
+// Push puts next on top of the stack. The previous top scene is paused
+// (see Pauser) and keeps drawing and updating according to mode.
+func (m *Manager) Push(next Scene, mode Mode) {
+	if next == nil {
+		return
 	}
+	if top := m.Current(); top != nil {
+		if p, ok := top.(Pauser); ok {
+			p.OnPause()
+		}
+	}
+	m.stack = append(m.stack, layer{scene: next, mode: mode})
+	next.OnEnter()
 }
 
-// Update forwards the update call to the active scene.
+// Pop removes the top scene, calling its OnExit, and resumes the scene
+// below it (see Pauser). It returns the removed scene, or nil when the
+// stack is empty.
+func (m *Manager) Pop() Scene {
+	if len(m.stack) == 0 {
+		return nil
+	}
+	top := m.stack[len(m.stack)-1]
+	m.stack = m.stack[:len(m.stack)-1]
+	top.scene.OnExit()
+	if next := m.Current(); next != nil {
+		if p, ok := next.(Pauser); ok {
+			p.OnResume()
+		}
+	}
+	return top.scene
+}
+
+// Current returns the top scene, or nil when the stack is empty.
+func (m *Manager) Current() Scene {
+	if len(m.stack) == 0 {
+		return nil
+	}
+	return m.stack[len(m.stack)-1].scene
+}
+
+// Len returns the number of scenes on the stack.
+func (m *Manager) Len() int {
+	return len(m.stack)
+}
+
+// Update updates the top scene and every scene below it that is not frozen
+// or covered, bottom to top. Scenes removed from the stack by an earlier
+// Update in the same frame are skipped. While a transition runs only the
+// transition advances.
 func (m *Manager) Update(dt float64) {
-	if m.current == nil {
+	if m.transition != nil {
+		m.advanceTransition(dt)
 		return
 	}
-	m.current.Update(dt)
+	if len(m.stack) == 0 {
+		return
+	}
+	first := len(m.stack) - 1
+	for first > 0 && m.stack[first].mode == Live {
+		first--
+	}
+	active := make([]Scene, 0, len(m.stack)-first)
+	for _, l := range m.stack[first:] {
+		active = append(active, l.scene)
+	}
+	for _, s := range active {
+		if m.contains(s) {
+			s.Update(dt)
+		}
+	}
 }
 
-// Draw forwards the draw call to the active scene.
+// Draw draws the visible scenes bottom to top, starting at the highest
+// scene that is covered by no scene above it.
 func (m *Manager) Draw(screen *ebiten.Image) {
-	if m.current == nil {
+	if m.transition != nil {
+		m.drawTransition(screen)
 		return
 	}
-	m.current.Draw(screen)
+	drawLayers(m.stack, screen)
+}
+
+func drawLayers(stack []layer, screen *ebiten.Image) {
+	if len(stack) == 0 {
+		return
+	}
+	first := len(stack) - 1
+	for first > 0 && stack[first].mode != Cover {
+		first--
+	}
+	for _, l := range stack[first:] {
+		l.scene.Draw(screen)
+	}
+}
+
+// contains reports whether s is still on the stack.
+func (m *Manager) contains(s Scene) bool {
+	return slices.ContainsFunc(m.stack, func(l layer) bool { return l.scene == s })
 }
@@ -0,0 +1,164 @@
+package scene
+
+import (
+	"image"
+	"reflect"
+	"testing"
+
+	"github.com/hajimehoshi/ebiten/v2"
+)
+
+// recorder is a scene that appends its lifecycle calls to a shared log.
+type recorder struct {
+	name string
+	log  *[]string
+}
+
+func (r *recorder) OnEnter()           { *r.log = append(*r.log, r.name+".enter") }
+func (r *recorder) OnExit()            { *r.log = append(*r.log, r.name+".exit") }
+func (r *recorder) OnPause()           { *r.log = append(*r.log, r.name+".pause") }
+func (r *recorder) OnResume()          { *r.log = append(*r.log, r.name+".resume") }
+func (r *recorder) Update(float64)     { *r.log = append(*r.log, r.name+".update") }
+func (r *recorder) Draw(*ebiten.Image) { *r.log = append(*r.log, r.name+".draw") }
+
+func expectLog(t *testing.T, log *[]string, want ...string) {
+	t.Helper()
+	if !reflect.DeepEqual(*log, want) {
+		t.Fatalf("log = %v, want %v", *log, want)
+	}
+	*log = nil
+}
+
+func TestManager_PushPopLifecycle(t *testing.T) {
+	var log []string
+	game := &recorder{name: "game", log: &log}
+	pause := &recorder{name: "pause", log: &log}
+
+	m := NewManager(game)
+	m.Push(pause, Freeze)
+	expectLog(t, &log, "game.enter", "game.pause", "pause.enter")
+	if m.Current() != pause || m.Len() != 2 {
+		t.Fatalf("Current() = %v, Len() = %d; want pause on top of 2 scenes", m.Current(), m.Len())
+	}
+
+	if popped := m.Pop(); popped != pause {
+		t.Fatalf("Pop() = %v, want pause", popped)
+	}
+	expectLog(t, &log, "pause.exit", "game.resume")
+}
+
+func TestManager_ModesControlUpdateAndDraw(t *testing.T) {
+	var log []string
+	game := &recorder{name: "game", log: &log}
+	overlay := &recorder{name: "overlay", log: &log}
+	m := NewManager(game)
+
+	cases := []struct {
+		mode       Mode
+		wantUpdate []string
+		wantDraw   []string
+	}{
+		{Freeze, []string{"overlay.update"}, []string{"game.draw", "overlay.draw"}},
+		{Live, []string{"game.update", "overlay.update"}, []string{"game.draw", "overlay.draw"}},
+		{Cover, []string{"overlay.update"}, []string{"overlay.draw"}},
+	}
+	for _, tc := range cases {
+		m.Push(overlay, tc.mode)
+		log = nil
+		m.Update(0.1)
+		expectLog(t, &log, tc.wantUpdate...)
+		m.Draw(nil)
+		expectLog(t, &log, tc.wantDraw...)
+		m.Pop()
+	}
+}
+
+func TestManager_SetSceneClearsStack(t *testing.T) {
+	var log []string
+	game := &recorder{name: "game", log: &log}
+	pause := &recorder{name: "pause", log: &log}
+	start := &recorder{name: "start", log: &log}
+
+	m := NewManager(game)
+	m.Push(pause, Freeze)
+	log = nil
+
+	m.SetScene(start)
+	expectLog(t, &log, "pause.exit", "game.exit", "start.enter")
+	if m.Len() != 1 || m.Current() != start {
+		t.Fatalf("stack after SetScene has %d scenes, top %v; want only start", m.Len(), m.Current())
+	}
+}
+
+func TestManager_TransitionSuppressesUpdatesUntilComplete(t *testing.T) {
+	var log []string
+	start := &recorder{name: "start", log: &log}
+	run := &recorder{name: "run", log: &log}
+
+	m := NewManager(start)
+	log = nil
+	m.Transition(run, Fade{Duration: 0.1})
+	expectLog(t, &log, "run.enter")
+	if !m.Transitioning() || m.Current() != run {
+		t.Fatalf("Transitioning() = %v, Current() = %v; want run entered while transitioning", m.Transitioning(), m.Current())
+	}
+
+	m.Update(0.03)
+	expectLog(t, &log)
+	m.Update(0.03)
+	m.Update(0.03)
+	m.Update(0.03)
+	expectLog(t, &log, "start.exit")
+	if m.Transitioning() {
+		t.Fatalf("transition still running after its duration")
+	}
+
+	m.Update(0.03)
+	expectLog(t, &log, "run.update")
+}
+
+func TestManager_ZeroLengthTransitionSwitchesImmediately(t *testing.T) {
+	var log []string
+	start := &recorder{name: "start", log: &log}
+	run := &recorder{name: "run", log: &log}
+
+	m := NewManager(start)
+	log = nil
+	m.Transition(run, Wipe{})
+	expectLog(t, &log, "run.enter", "start.exit")
+	if m.Transitioning() {
+		t.Fatalf("zero-length transition must not stay active")
+	}
+}
+
+func TestWipeRect_RevealsFromTheEdgeItTravelsAwayFrom(t *testing.T) {
+	bounds := image.Rect(0, 0, 100, 50)
+	cases := map[WipeDirection]image.Rectangle{
+		WipeRight: image.Rect(0, 0, 25, 50),
+		WipeLeft:  image.Rect(75, 0, 100, 50),
+		WipeDown:  image.Rect(0, 0, 100, 12),
+		WipeUp:    image.Rect(0, 38, 100, 50),
+	}
+	for dir, want := range cases {
+		if got := wipeRect(bounds, dir, 0.25); got != want {
+			t.Errorf("wipeRect(%v, 0.25) = %v, want %v", dir, got, want)
+		}
+	}
+}
+
+func TestFadeAlpha_PeaksAtTheMidpointAndClearsAtTheEnds(t *testing.T) {
+	cases := map[float64]float64{
+		-0.5: 0,
+		0:    0,
+		0.25: 0.5,
+		0.5:  1,
+		0.75: 0.5,
+		1:    0,
+		1.5:  0,
+	}
+	for p, want := range cases {
+		if got := fadeAlpha(p); got != want {
+			t.Errorf("fadeAlpha(%v) = %v, want %v", p, got, want)
+		}
+	}
+}
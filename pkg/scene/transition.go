@@ -0,0 +1,203 @@
+package scene
+
+import (
+	"image"
+	"image/color"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/vector"
+)
+
+// maxTransitionStep caps how far a single frame advances a transition, so a
+// slow frame (for example one that constructs the incoming scene) does not
+// skip the effect.
+const maxTransitionStep = 1.0 / 30
+
+// Transition is a visual effect used when switching scenes with
+// Manager.Transition.
+type Transition interface {
+	// Length returns the duration of the transition in seconds.
+	Length() float64
+	// Draw renders the transition onto screen at progress p in [0, 1], given
+	// offscreen renderings of the outgoing and incoming scenes.
+	Draw(screen, from, to *ebiten.Image, p float64)
+}
+
+// activeTransition is a transition in progress.
+type activeTransition struct {
+	effect  Transition
+	from    []layer
+	elapsed float64
+}
+
+// progress returns how far the transition has advanced, in [0, 1].
+func (t *activeTransition) progress() float64 {
+	length := t.effect.Length()
+	if length <= 0 || t.elapsed >= length {
+		return 1
+	}
+	return t.elapsed / length
+}
+
+// Transition replaces the whole stack with next like SetScene, animating the
+// switch with effect. next is entered immediately; the outgoing scenes keep
+// being drawn until the transition completes and are exited then. No scene
+// is updated while a transition runs, so input is ignored until it ends.
+func (m *Manager) Transition(next Scene, effect Transition) {
+	m.finishTransition()
+	from := m.stack
+	m.stack = nil
+	if next != nil {
+		m.stack = append(m.stack, layer{scene: next, mode: Cover})
+		next.OnEnter()
+	}
+	m.transition = &activeTransition{effect: effect, from: from}
+	if effect == nil || effect.Length() <= 0 {
+		m.finishTransition()
+	}
+}
+
+// Transitioning reports whether a transition is in progress.
+func (m *Manager) Transitioning() bool {
+	return m.transition != nil
+}
+
+func (m *Manager) advanceTransition(dt float64) {
+	m.transition.elapsed += min(dt, maxTransitionStep)
+	if m.transition.progress() >= 1 {
+		m.finishTransition()
+	}
+}
+
+// finishTransition ends the running transition, if any, exiting the
+// outgoing scenes from the top down.
+func (m *Manager) finishTransition() {
+	if m.transition == nil {
+		return
+	}
+	from := m.transition.from
+	m.transition = nil
+	for i := len(from) - 1; i >= 0; i-- {
+		from[i].scene.OnExit()
+	}
+}
+
+func (m *Manager) drawTransition(screen *ebiten.Image) {
+	bounds := screen.Bounds()
+	m.fromImage = offscreen(m.fromImage, bounds)
+	m.toImage = offscreen(m.toImage, bounds)
+	drawLayers(m.transition.from, m.fromImage)
+	drawLayers(m.stack, m.toImage)
+	m.transition.effect.Draw(screen, m.fromImage, m.toImage, m.transition.progress())
+}
+
+// offscreen returns a cleared image of the given size, reusing img when it
+// already has that size.
+func offscreen(img *ebiten.Image, bounds image.Rectangle) *ebiten.Image {
+	if img == nil || img.Bounds().Size() != bounds.Size() {
+		if img != nil {
+			img.Deallocate()
+		}
+		return ebiten.NewImage(bounds.Dx(), bounds.Dy())
+	}
+	img.Clear()
+	return img
+}
+
+// Fade fades the outgoing scene to a solid color during the first half and
+// the incoming scene in from that color during the second half.
+type Fade struct {
+	Duration float64
+	// Color is the color faded through; nil means black.
+	Color color.Color
+}
+
+func (f Fade) Length() float64 { return f.Duration }
+
+func (f Fade) Draw(screen, from, to *ebiten.Image, p float64) {
+	if p < 0.5 {
+		screen.DrawImage(from, nil)
+	} else {
+		screen.DrawImage(to, nil)
+	}
+	alpha := fadeAlpha(p)
+	c := f.Color
+	if c == nil {
+		c = color.Black
+	}
+	r, g, b, _ := c.RGBA()
+	overlay := color.NRGBA{R: uint8(r >> 8), G: uint8(g >> 8), B: uint8(b >> 8), A: uint8(alpha * 255)}
+	size := screen.Bounds().Size()
+	vector.FillRect(screen, 0, 0, float32(size.X), float32(size.Y), overlay, false)
+}
+
+// fadeAlpha returns the overlay opacity of a Fade at progress p: it rises
+// from 0 to 1 over the first half and falls back to 0 over the second.
+func fadeAlpha(p float64) float64 {
+	alpha := 2 * p
+	if p >= 0.5 {
+		alpha = 2 - 2*p
+	}
+	return max(0, min(alpha, 1))
+}
+
+// Crossfade blends the incoming scene over the outgoing one.
+type Crossfade struct {
+	Duration float64
+}
+
+func (c Crossfade) Length() float64 { return c.Duration }
+
+func (c Crossfade) Draw(screen, from, to *ebiten.Image, p float64) {
+	screen.DrawImage(from, nil)
+	op := &ebiten.DrawImageOptions{}
+	op.ColorScale.ScaleAlpha(float32(p))
+	screen.DrawImage(to, op)
+}
+
+// WipeDirection is the direction in which a Wipe's edge travels.
+type WipeDirection int
+
+const (
+	WipeRight WipeDirection = iota
+	WipeLeft
+	WipeDown
+	WipeUp
+)
+
+// Wipe reveals the incoming scene behind an edge sweeping across the screen.
+type Wipe struct {
+	Duration  float64
+	Direction WipeDirection
+}
+
+func (w Wipe) Length() float64 { return w.Duration }
+
+func (w Wipe) Draw(screen, from, to *ebiten.Image, p float64) {
+	screen.DrawImage(from, nil)
+	revealed := wipeRect(to.Bounds(), w.Direction, p)
+	if revealed.Empty() {
+		return
+	}
+	op := &ebiten.DrawImageOptions{}
+	op.GeoM.Translate(float64(revealed.Min.X), float64(revealed.Min.Y))
+	screen.DrawImage(to.SubImage(revealed).(*ebiten.Image), op)
+}
+
+// wipeRect returns the part of bounds revealed at progress p.
+func wipeRect(bounds image.Rectangle, dir WipeDirection, p float64) image.Rectangle {
+	r := bounds
+	dx := int(float64(bounds.Dx()) * p)
+	dy := int(float64(bounds.Dy()) * p)
+	switch dir {
+	case WipeRight:
+		r.Max.X = bounds.Min.X + dx
+	case WipeLeft:
+		r.Min.X = bounds.Max.X - dx
+	case WipeDown:
+		r.Max.Y = bounds.Min.Y + dy
+	case WipeUp:
+		r.Min.Y = bounds.Max.Y - dy
+	}
+	return r
+}